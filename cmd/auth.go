@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/momorph/cli/internal/auth"
+	clierrors "github.com/momorph/cli/internal/errors"
+	"github.com/momorph/cli/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage the stored MoMorph session",
+}
+
+var authRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Re-validate the stored credentials with GitHub and MoMorph",
+	Long: `Re-validate the stored GitHub token with GitHub and MoMorph and save it
+again with its current scopes.
+
+MoMorph uses the GitHub OAuth token directly, and GitHub OAuth tokens do not
+expire on a schedule; they stay valid until revoked. Running this before a
+batch of uploads (e.g. on a CI runner) surfaces a revoked or unauthorized
+token up front instead of in the middle of the run.`,
+	Example: `  momorph auth refresh`,
+	RunE:    runAuthRefresh,
+}
+
+func init() {
+	authCmd.AddCommand(authRefreshCmd)
+	rootCmd.AddCommand(authCmd)
+}
+
+// errNotAuthenticated is returned by commands that need a stored login, after
+// they have told the user to run 'momorph login', so it is not printed again
+func errNotAuthenticated() error {
+	return clierrors.NewAuthError(nil, "not authenticated").AsReported()
+}
+
+func runAuthRefresh(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	token, err := auth.LoadToken()
+	if err != nil || !token.IsValid() {
+		return clierrors.NewAuthError(err, "not authenticated, run 'momorph login' first")
+	}
+
+	// Check the GitHub token itself first so a revoked token is reported as such
+	fmt.Println("🔄 Checking GitHub token...")
+	githubUser, err := auth.GetAuthenticatedUser(ctx, token.GitHubToken)
+	if err != nil {
+		logger.Error("GitHub token check failed", err)
+		return clierrors.NewAuthError(err, "GitHub token is no longer valid, run 'momorph login' to sign in again")
+	}
+
+	fmt.Println("🔄 Checking MoMorph session...")
+	user, err := auth.GetMoMorphUser(ctx, token.GitHubToken)
+	if err != nil {
+		logger.Error("MoMorph session check failed", err)
+		return clierrors.NewAuthError(err, "MoMorph rejected the session")
+	}
+
+	token.GitHubScopes = githubUser.Scopes
+	token.SetUser(user)
+	if err := auth.SaveToken(token); err != nil {
+		logger.Error("Failed to save token", err)
+		return fmt.Errorf("failed to save token: %w", err)
+	}
+
+	fmt.Printf("✓ Session refreshed for %s\n", maskEmail(user.Email))
+	if len(githubUser.Scopes) > 0 {
+		fmt.Printf("  Scopes:  %s\n", strings.Join(githubUser.Scopes, ", "))
+	}
+	fmt.Println("  Expires: never (valid until the GitHub token is revoked)")
+
+	return nil
+}
+
+// checkSessionBeforeUpload verifies the stored GitHub token before a batch
+// upload starts. GitHub tokens have no expiry time to check ahead of time,
+// but a revoked token would otherwise fail the run after some files were
+// already uploaded. Only a rejected token is an error; if GitHub cannot be
+// reached the upload goes ahead and MoMorph has the final say.
+func checkSessionBeforeUpload(ctx context.Context) error {
+	token, err := auth.LoadToken()
+	if err != nil || !token.IsValid() {
+		return clierrors.NewAuthError(err, "not authenticated, run 'momorph login' first")
+	}
+
+	if _, err := auth.GetAuthenticatedUser(ctx, token.GitHubToken); err != nil {
+		if errors.Is(err, auth.ErrInvalidGitHubToken) {
+			return clierrors.NewAuthError(err, "your GitHub token is no longer valid, nothing was uploaded; run 'momorph login' to sign in again")
+		}
+		logger.Warn("Could not verify GitHub token before upload: %v", err)
+	}
+	return nil
+}
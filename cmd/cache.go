@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/momorph/cli/internal/config"
+	"github.com/momorph/cli/internal/logger"
+	"github.com/momorph/cli/internal/template"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// cacheAI is the AI tool whose template is added or exported
+	cacheAI string
+	// cacheVersion is the template version recorded by cache add; init looks
+	// up "default" when run without --tag
+	cacheVersion string
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the local template cache",
+	Long: `Manage the cache of downloaded project templates, one per AI tool.
+
+For air-gapped machines, add a template zip to the cache ahead of time:
+'momorph init' uses a template cached in the last 24 hours for its AI tool
+and --tag when the template cannot be fetched. Or export a cached template
+and pass it to 'momorph init --template-file'.`,
+}
+
+var cacheAddCmd = &cobra.Command{
+	Use:   "add <file.zip>",
+	Short: "Add a template zip to the cache",
+	Example: `  momorph cache add --ai cursor template-cursor.zip
+  momorph cache add --ai claude --version stable template-claude.zip`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCacheAdd,
+}
+
+var cacheExportCmd = &cobra.Command{
+	Use:   "export <dest.zip>",
+	Short: "Copy a cached template out of the cache",
+	Example: `  momorph cache export --ai cursor template-cursor.zip
+  momorph init my-project --ai cursor --template-file template-cursor.zip`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCacheExport,
+}
+
+func init() {
+	for _, c := range []*cobra.Command{cacheAddCmd, cacheExportCmd} {
+		c.Flags().StringVar(&cacheAI, "ai", "", "AI tool of the template ("+strings.Join(config.AITools, ", ")+")")
+		c.MarkFlagRequired("ai")
+		c.RegisterFlagCompletionFunc("ai", completeAITools)
+	}
+	cacheAddCmd.Flags().StringVar(&cacheVersion, "version", "default", "Template version tag to record (stable, latest, or specific version)")
+	cacheCmd.AddCommand(cacheAddCmd)
+	cacheCmd.AddCommand(cacheExportCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
+
+func runCacheAdd(cmd *cobra.Command, args []string) error {
+	if !config.IsValidAITool(cacheAI) {
+		return fmt.Errorf("invalid AI tool: %s (must be one of: %s)", cacheAI, strings.Join(config.AITools, ", "))
+	}
+	if cacheVersion == "" {
+		return fmt.Errorf("--version cannot be empty")
+	}
+
+	srcPath, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid template file: %w", err)
+	}
+	if err := template.CheckArchive(srcPath); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to read template file: %w", err)
+	}
+
+	cache, err := template.NewCache()
+	if err != nil {
+		return err
+	}
+	if err := cache.Put(cacheAI, cacheVersion, "file://"+filepath.ToSlash(srcPath), data); err != nil {
+		logger.Error("Failed to add template to cache", err)
+		return fmt.Errorf("failed to add template to cache: %w", err)
+	}
+
+	fmt.Printf("✓ Cached %s template (version %s) from %s\n", cacheAI, cacheVersion, args[0])
+	return nil
+}
+
+func runCacheExport(cmd *cobra.Command, args []string) error {
+	if !config.IsValidAITool(cacheAI) {
+		return fmt.Errorf("invalid AI tool: %s (must be one of: %s)", cacheAI, strings.Join(config.AITools, ", "))
+	}
+
+	cache, err := template.NewCache()
+	if err != nil {
+		return err
+	}
+	src, err := cache.GetCachedFile(cacheAI)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	destPath := args[0]
+	dst, err := os.Create(destPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", destPath, err)
+	}
+	_, err = io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(destPath)
+		return fmt.Errorf("failed to write %s: %w", destPath, err)
+	}
+
+	fmt.Printf("✓ Exported cached %s template to %s\n", cacheAI, destPath)
+	return nil
+}
@@ -1,8 +1,16 @@
 package cmd
 
 import (
+	"fmt"
+	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
 
+	"github.com/momorph/cli/internal/config"
+	"github.com/momorph/cli/internal/upload"
 	"github.com/spf13/cobra"
 )
 
@@ -24,7 +32,10 @@ var completionCmd = &cobra.Command{
   momorph completion fish > ~/.config/fish/completions/momorph.fish
 
   # PowerShell
-  momorph completion powershell >> $PROFILE`,
+  momorph completion powershell >> $PROFILE
+
+  # Or let momorph pick the location for your shell
+  momorph completion install`,
 	DisableFlagsInUseLine: true,
 }
 
@@ -94,10 +105,247 @@ var completionPowershellCmd = &cobra.Command{
 	},
 }
 
+var completionInstallCmd = &cobra.Command{
+	Use:   "install [bash|zsh|fish|powershell]",
+	Short: "Install the completion script for your shell",
+	Long: `Write the completion script to the conventional location for the shell.
+
+The shell is taken from the argument, or detected from $SHELL (PowerShell
+on Windows). No root access is needed: on macOS with Homebrew the script goes
+into the Homebrew prefix, elsewhere into your user directories.`,
+	Example: `  momorph completion install        # Detect the shell from $SHELL
+  momorph completion install zsh    # Install for zsh`,
+	DisableFlagsInUseLine: true,
+	Args:                  cobra.MaximumNArgs(1),
+	ValidArgs:             completionShells,
+	RunE:                  runCompletionInstall,
+}
+
+var completionUninstallCmd = &cobra.Command{
+	Use:   "uninstall [bash|zsh|fish|powershell]",
+	Short: "Remove the completion script installed by 'completion install'",
+	Example: `  momorph completion uninstall        # Detect the shell from $SHELL
+  momorph completion uninstall zsh    # Uninstall for zsh`,
+	DisableFlagsInUseLine: true,
+	Args:                  cobra.MaximumNArgs(1),
+	ValidArgs:             completionShells,
+	RunE:                  runCompletionUninstall,
+}
+
+// completionShells are the shells completion scripts can be generated for
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
 func init() {
+	completionCmd.AddCommand(completionInstallCmd)
+	completionCmd.AddCommand(completionUninstallCmd)
 	completionCmd.AddCommand(completionBashCmd)
 	completionCmd.AddCommand(completionZshCmd)
 	completionCmd.AddCommand(completionFishCmd)
 	completionCmd.AddCommand(completionPowershellCmd)
 	rootCmd.AddCommand(completionCmd)
 }
+
+func runCompletionInstall(cmd *cobra.Command, args []string) error {
+	shell, err := completionShellArg(args)
+	if err != nil {
+		return err
+	}
+
+	path, hint, err := completionInstallPath(shell)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to write completion script: %w", err)
+	}
+	if err := writeCompletion(shell, file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to generate %s completion: %w", shell, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write completion script: %w", err)
+	}
+
+	fmt.Printf("✓ Installed %s completion to %s\n", shell, path)
+	if hint != "" {
+		fmt.Println("\n" + hint)
+	}
+	fmt.Println("\nRestart your shell to enable completion.")
+	return nil
+}
+
+func runCompletionUninstall(cmd *cobra.Command, args []string) error {
+	shell, err := completionShellArg(args)
+	if err != nil {
+		return err
+	}
+
+	path, _, err := completionInstallPath(shell)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			fmt.Printf("No %s completion installed at %s\n", shell, path)
+			return nil
+		}
+		return fmt.Errorf("failed to remove completion script: %w", err)
+	}
+
+	fmt.Printf("✓ Removed %s completion from %s\n", shell, path)
+	if shell == "powershell" {
+		fmt.Println("\nAlso remove the line loading it from your PowerShell profile ($PROFILE).")
+	}
+	return nil
+}
+
+// completionShellArg returns the shell named in args, or the detected one
+func completionShellArg(args []string) (string, error) {
+	if len(args) > 0 {
+		return strings.ToLower(args[0]), nil
+	}
+	shell := detectShell()
+	if shell == "" {
+		return "", fmt.Errorf("could not detect your shell, pass one of: %s", strings.Join(completionShells, ", "))
+	}
+	return shell, nil
+}
+
+// detectShell returns the user's shell from $SHELL, or powershell on Windows
+func detectShell() string {
+	if shell := os.Getenv("SHELL"); shell != "" {
+		name := strings.TrimSuffix(filepath.Base(shell), ".exe")
+		for _, known := range completionShells {
+			if name == known {
+				return name
+			}
+		}
+		if name == "pwsh" {
+			return "powershell"
+		}
+		return ""
+	}
+	if runtime.GOOS == "windows" {
+		return "powershell"
+	}
+	return ""
+}
+
+// completionInstallPath returns where the completion script for shell goes,
+// plus any setup the user still has to do for the shell to load it
+func completionInstallPath(shell string) (path string, hint string, err error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(home, ".config")
+	}
+
+	switch shell {
+	case "bash":
+		if prefix := brewPrefix(); prefix != "" {
+			return filepath.Join(prefix, "etc", "bash_completion.d", "momorph"), "", nil
+		}
+		// Loaded on demand by bash-completion 2.x
+		return filepath.Join(dataHome, "bash-completion", "completions", "momorph"),
+			"Requires the bash-completion package.", nil
+	case "zsh":
+		if prefix := brewPrefix(); prefix != "" {
+			return filepath.Join(prefix, "share", "zsh", "site-functions", "_momorph"), "", nil
+		}
+		dir := filepath.Join(home, ".zsh", "completions")
+		return filepath.Join(dir, "_momorph"),
+			fmt.Sprintf("If not done already, add this to ~/.zshrc:\n  fpath=(%s $fpath)\n  autoload -U compinit && compinit", dir), nil
+	case "fish":
+		return filepath.Join(configHome, "fish", "completions", "momorph.fish"), "", nil
+	case "powershell":
+		path := filepath.Join(configHome, "momorph", "completion.ps1")
+		if runtime.GOOS == "windows" {
+			if dir, err := os.UserConfigDir(); err == nil {
+				path = filepath.Join(dir, "momorph", "completion.ps1")
+			}
+		}
+		return path, fmt.Sprintf("Add this line to your PowerShell profile ($PROFILE):\n  . '%s'", path), nil
+	default:
+		return "", "", fmt.Errorf("unsupported shell %q (must be one of: %s)", shell, strings.Join(completionShells, ", "))
+	}
+}
+
+// brewPrefix returns the Homebrew prefix on macOS, or "" if Homebrew is not installed
+func brewPrefix() string {
+	if runtime.GOOS != "darwin" {
+		return ""
+	}
+	out, err := exec.Command("brew", "--prefix").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// writeCompletion writes the completion script for shell to w
+func writeCompletion(shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(w, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(w)
+	case "fish":
+		return rootCmd.GenFishCompletion(w, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(w)
+	default:
+		return fmt.Errorf("unsupported shell %q", shell)
+	}
+}
+
+// completeAITools completes --ai with the supported AI tools
+func completeAITools(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return config.AITools, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeUploadFiles returns a completion for upload file arguments that
+// lists the CSV files under .momorph/{uploadType} matching the upload path
+// pattern. Without matches the shell falls back to file completion.
+func completeUploadFiles(uploadType string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		files, err := upload.ResolveFiles(nil, "", true, uploadType)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		cwd, _ := os.Getwd()
+
+		given := make(map[string]bool)
+		for _, arg := range args {
+			if abs, err := filepath.Abs(arg); err == nil {
+				given[abs] = true
+			}
+		}
+
+		var candidates []string
+		for _, file := range files {
+			if given[file] {
+				continue
+			}
+			if rel, err := filepath.Rel(cwd, file); err == nil {
+				file = rel
+			}
+			if strings.HasPrefix(file, toComplete) {
+				candidates = append(candidates, file)
+			}
+		}
+		return candidates, cobra.ShellCompDirectiveDefault
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/momorph/cli/internal/logger"
+	"github.com/momorph/cli/internal/vscode"
+	"github.com/spf13/cobra"
+)
+
+// extensionVersion pins the version installed by extension install/update
+var extensionVersion string
+
+var extensionCmd = &cobra.Command{
+	Use:   "extension",
+	Short: "Manage the MoMorph VS Code extension",
+	Long: `Install, update or remove the MoMorph VS Code extension (` + vscode.ExtensionName + `).
+
+'momorph init' installs the extension automatically; use these commands to
+retry a failed install or to upgrade without re-initializing the project.
+The VS Code CLI ('code' or 'code-insiders') must be available.`,
+}
+
+var extensionInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the MoMorph VS Code extension if it is not installed",
+	Example: `  momorph extension install
+  momorph extension install --version 1.4.2   # A known-good version`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Println("📦 Installing MoMorph VS Code extension...")
+		return reportExtensionResult(vscode.InstallExtension(GetContext(), extensionVersion), true)
+	},
+}
+
+var extensionUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Reinstall the latest MoMorph VS Code extension",
+	Example: `  momorph extension update
+  momorph extension update --version 1.4.2    # Reinstall a specific version`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Println("📦 Installing the latest MoMorph VS Code extension...")
+		return reportExtensionResult(vscode.UpdateExtension(GetContext(), extensionVersion), true)
+	},
+}
+
+var extensionUninstallCmd = &cobra.Command{
+	Use:     "uninstall",
+	Short:   "Uninstall the MoMorph VS Code extension",
+	Example: `  momorph extension uninstall`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Println("🗑  Uninstalling MoMorph VS Code extension...")
+		return reportExtensionResult(vscode.UninstallExtension(), false)
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{extensionInstallCmd, extensionUpdateCmd} {
+		c.Flags().StringVar(&extensionVersion, "version", "", "Install this extension version (e.g. 1.4.2 or a .vsix file name) instead of the latest")
+	}
+	extensionCmd.AddCommand(extensionInstallCmd)
+	extensionCmd.AddCommand(extensionUpdateCmd)
+	extensionCmd.AddCommand(extensionUninstallCmd)
+	rootCmd.AddCommand(extensionCmd)
+}
+
+// reportExtensionResult prints result and returns an error unless the
+// extension ended up in the wanted state (installed or not)
+func reportExtensionResult(result vscode.InstallResult, wantInstalled bool) error {
+	if result.Error != nil {
+		logger.Warn("Extension command failed: %v", result.Error)
+		fmt.Printf("✗ %s\n", result.Message)
+		return fmt.Errorf("extension command failed: %w", result.Error)
+	}
+	if result.Installed != wantInstalled {
+		fmt.Printf("✗ %s\n", result.Message)
+		return fmt.Errorf("%s", result.Message)
+	}
+	fmt.Printf("✓ %s\n", result.Message)
+	return nil
+}
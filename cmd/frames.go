@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/momorph/cli/internal/auth"
+	"github.com/momorph/cli/internal/graphql"
+	"github.com/momorph/cli/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var (
+	framesFileKey string
+	framesJSON    bool
+)
+
+var framesCmd = &cobra.Command{
+	Use:   "frames",
+	Short: "Inspect frames on MoMorph server",
+}
+
+var framesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the frames of a design file",
+	Long: `List the frames of a design file with their IDs, names and status.
+
+The frame link ID is the {frame_id} part of upload paths:
+  .momorph/{testcases|specs}/{file_key}/{frame_id}-{frame_name}.csv
+
+Frames in 'design' status do not accept test case uploads yet.`,
+	Example: `  momorph frames list --file-key i09vM3jClQiu8cwXsMo6uy
+  momorph frames list --file-key i09vM3jClQiu8cwXsMo6uy --json`,
+	RunE: runFramesList,
+}
+
+func init() {
+	framesListCmd.Flags().StringVar(&framesFileKey, "file-key", "", "Design file key")
+	framesListCmd.Flags().BoolVar(&framesJSON, "json", false, "Print frames as JSON")
+	framesListCmd.MarkFlagRequired("file-key")
+	framesCmd.AddCommand(framesListCmd)
+	rootCmd.AddCommand(framesCmd)
+}
+
+func runFramesList(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	// Check authentication
+	if !auth.IsAuthenticated() {
+		fmt.Println("✗ Not authenticated")
+		fmt.Println("\nRun 'momorph login' to authenticate with GitHub and MoMorph")
+		return errNotAuthenticated()
+	}
+
+	client, err := graphql.NewClient()
+	if err != nil {
+		logger.Error("Failed to create GraphQL client", err)
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	frames, err := client.ListFrames(ctx, framesFileKey)
+	if err != nil {
+		logger.Error("Failed to list frames", err)
+		return fmt.Errorf("failed to list frames: %w", err)
+	}
+
+	if framesJSON {
+		if frames == nil {
+			frames = []graphql.Frame{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(frames)
+	}
+
+	if len(frames) == 0 {
+		fmt.Printf("No frames found for file %s\n", framesFileKey)
+		return nil
+	}
+
+	rows := make([][]string, len(frames))
+	for i, frame := range frames {
+		rows[i] = []string{strconv.Itoa(frame.ID), frame.FrameLinkID, frame.Name, frame.Status}
+	}
+
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("243"))).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			return lipgloss.NewStyle().Padding(0, 1)
+		}).
+		Headers("ID", "Frame Link ID", "Name", "Status").
+		Rows(rows...)
+
+	fmt.Println(t.String())
+	fmt.Printf("%d frame(s)\n", len(frames))
+	return nil
+}
@@ -66,6 +66,10 @@ func PrintBanner(w io.Writer) {
 
 // isColorEnabled checks if color output should be enabled
 func isColorEnabled() bool {
+	// Disable colors if --no-color was passed
+	if noColor {
+		return false
+	}
 	// Disable colors if NO_COLOR env var is set (https://no-color.org/)
 	if os.Getenv("NO_COLOR") != "" {
 		return false
@@ -4,14 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/momorph/cli/internal/api"
 	"github.com/momorph/cli/internal/auth"
 	"github.com/momorph/cli/internal/config"
+	clierrors "github.com/momorph/cli/internal/errors"
+	"github.com/momorph/cli/internal/i18n"
 	"github.com/momorph/cli/internal/logger"
 	"github.com/momorph/cli/internal/template"
 	"github.com/momorph/cli/internal/ui"
@@ -22,6 +27,20 @@ import (
 var (
 	aiTool      string
 	templateTag string
+	initForce   bool
+	initOutput  string
+	initListAI  bool
+	// initExtensionVersion pins the VS Code extension version to install
+	initExtensionVersion string
+	// initNoExtension and initNoMCPConfig skip init's optional side effects
+	initNoExtension bool
+	initNoMCPConfig bool
+	// initTemplateFile is a local template zip used instead of downloading one
+	initTemplateFile string
+	// initTemplateURL is an HTTPS URL of a template zip, e.g. a private mirror
+	initTemplateURL string
+	// initDryRun lists what init would write without touching the directory
+	initDryRun bool
 	// ErrUserCancelled is returned when the user cancels an operation
 	ErrUserCancelled = errors.New("user cancelled")
 )
@@ -31,61 +50,119 @@ var initCmd = &cobra.Command{
 	Short: "Initialize a new MoMorph project from the latest template",
 	Example: `  momorph init my-project --ai=copilot
   momorph init . --ai=cursor
-  momorph init my-project`,
-	Args: cobra.ExactArgs(1),
+  momorph init my-project
+  momorph init . --ai=claude --force   # Non-interactive, e.g. in CI
+  momorph init my-project -o ./apps/web --ai=cursor   # Name and directory differ
+  momorph init . --ai=claude --no-extension --no-mcp-config   # Template files only
+  momorph init my-project --ai=cursor --template-file ./template.zip   # Offline, from a local zip
+  momorph init my-project --ai=cursor --template-url https://mirror.example.com/template.zip
+  momorph init . --ai=claude --dry-run   # List new, merged and overwritten files, write nothing
+  momorph init --list-tools   # Show supported AI tools and their MCP config files`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if initListAI {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: runInit,
 }
 
 func init() {
-	initCmd.Flags().StringVar(&aiTool, "ai", "", "AI tool to use (copilot, cursor, claude, windsurf, gemini)")
+	initCmd.Flags().StringVar(&aiTool, "ai", "", "AI tool to use ("+strings.Join(config.AITools, ", ")+")")
+	initCmd.RegisterFlagCompletionFunc("ai", completeAITools)
 	initCmd.Flags().StringVar(&templateTag, "tag", "", "Template version tag (stable, latest, or specific version)")
+	initCmd.Flags().BoolVarP(&initForce, "force", "f", false, "Initialize into a non-empty directory without asking for confirmation")
+	initCmd.Flags().StringVarP(&initOutput, "output", "o", "", "Target directory (default: the project-name argument)")
+	initCmd.Flags().StringVar(&initExtensionVersion, "extension-version", "", "Install this VS Code extension version (e.g. 1.4.2) instead of the latest")
+	initCmd.Flags().BoolVar(&initNoExtension, "no-extension", false, "Don't install the MoMorph VS Code extension")
+	initCmd.Flags().BoolVar(&initNoMCPConfig, "no-mcp-config", false, "Don't write the GitHub token into the AI tool's MCP config")
+	initCmd.Flags().StringVar(&initTemplateFile, "template-file", "", "Extract this local template zip instead of downloading one")
+	initCmd.Flags().StringVar(&initTemplateURL, "template-url", "", "Download the template zip from this HTTPS URL instead of the MoMorph server")
+	initCmd.MarkFlagsMutuallyExclusive("template-file", "template-url", "tag")
+	initCmd.Flags().BoolVar(&initDryRun, "dry-run", false, "List the files init would create, merge or overwrite without writing anything")
+	initCmd.Flags().BoolVar(&initListAI, "list-tools", false, "List supported AI tools and the MCP config file each one uses, then exit")
 	rootCmd.AddCommand(initCmd)
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	if initListAI {
+		return listAITools()
+	}
+
 	projectName := args[0]
 
-	// Setup signal handling for graceful cancellation
-	ctx, cancel := context.WithCancel(ctx)
+	// Setup signal handling for graceful cancellation: the context aborts
+	// in-flight downloads, which clean up their partial files
+	ctx, cancel := context.WithCancel(GetContext())
 	defer cancel()
 
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
 	go func() {
-		<-sigChan
-		fmt.Println("\n\n✗ Initialization cancelled")
-		cancel()
-		os.Exit(0)
+		select {
+		case <-sigChan:
+			fmt.Println("\n\n✗ " + i18n.T("init.cancelled"))
+			cancel()
+		case <-ctx.Done():
+		}
 	}()
 
-	// Check authentication
-	if !auth.IsAuthenticated() {
-		fmt.Println("✗ Not authenticated")
-		fmt.Println("\nRun 'momorph login' to authenticate before initializing projects")
-		return nil
+	// Validate a local template up front; it needs no server access
+	if initTemplateFile != "" {
+		absPath, err := filepath.Abs(initTemplateFile)
+		if err != nil {
+			return fmt.Errorf("failed to resolve path: %w", err)
+		}
+		if err := template.CheckArchive(absPath); err != nil {
+			return fmt.Errorf("invalid template file %s: %w", initTemplateFile, err)
+		}
+		initTemplateFile = absPath
+	}
+
+	if initTemplateURL != "" {
+		u, err := url.Parse(initTemplateURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("invalid --template-url %q: must be an https:// URL", initTemplateURL)
+		}
 	}
 
-	// Determine target directory
+	// Check authentication; a local template is usable offline
+	if initTemplateFile == "" && !auth.IsAuthenticated() {
+		fmt.Println(i18n.T("auth.not_authenticated"))
+		fmt.Println("\n" + i18n.T("auth.login_before_init"))
+		return errNotAuthenticated()
+	}
+
+	// Determine target directory: --output, else the project name doubles as the directory
+	dirArg := projectName
+	if initOutput != "" {
+		dirArg = initOutput
+	}
 	var targetDir string
-	if projectName == "." {
+	if dirArg == "." {
 		var err error
 		targetDir, err = os.Getwd()
 		if err != nil {
 			return fmt.Errorf("failed to get current directory: %w", err)
 		}
 	} else {
-		absPath, err := filepath.Abs(projectName)
+		absPath, err := filepath.Abs(dirArg)
 		if err != nil {
 			return fmt.Errorf("failed to resolve path: %w", err)
 		}
 		targetDir = absPath
 	}
 
-	// Check if directory exists and is not empty
-	if err := checkDirectory(targetDir); err != nil {
+	// Check if directory exists and is not empty; a dry run changes nothing,
+	// so there is nothing to confirm
+	if initDryRun {
+		if info, err := os.Stat(targetDir); err == nil && !info.IsDir() {
+			return fmt.Errorf("path exists but is not a directory: %s", targetDir)
+		}
+	} else if err := checkDirectory(targetDir, initForce); err != nil {
 		if errors.Is(err, ErrUserCancelled) {
-			fmt.Println("Initialization cancelled")
+			fmt.Println(i18n.T("init.cancelled"))
 			return nil
 		}
 		return err
@@ -93,6 +170,9 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	// Prompt for AI tool if not provided
 	if aiTool == "" {
+		if !ui.IsInteractive() {
+			return fmt.Errorf("--ai is required when not running in a terminal (one of: %s)", strings.Join(config.AITools, ", "))
+		}
 		selectedTool, err := ui.PromptAITool()
 		if err != nil {
 			return fmt.Errorf("failed to get AI tool selection: %w", err)
@@ -101,35 +181,194 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Validate AI tool
-	validTools := map[string]bool{
-		"copilot":  true,
-		"cursor":   true,
-		"claude":   true,
-		"windsurf": true,
-		"gemini":   true,
+	if !config.IsValidAITool(aiTool) {
+		return fmt.Errorf("invalid AI tool: %s (must be one of: %s)", aiTool, strings.Join(config.AITools, ", "))
+	}
+
+	if initNoExtension && initExtensionVersion != "" {
+		return fmt.Errorf("--extension-version cannot be used with --no-extension")
 	}
-	if !validTools[aiTool] {
-		return fmt.Errorf("invalid AI tool: %s (must be one of: copilot, cursor, claude, windsurf, gemini)", aiTool)
+
+	// Validate a pinned extension version before doing any work
+	if initExtensionVersion != "" {
+		if _, err := vscode.ResolveVSIXFilename(initExtensionVersion); err != nil {
+			return err
+		}
 	}
 
-	fmt.Printf("🚀 Initializing MoMorph project with %s\n", aiTool)
+	infoln(i18n.T("init.starting", aiTool))
 
+	// Use the local template, or fetch it from the server. A dry run
+	// prefers a cached copy; a fetch that fails, e.g. offline, falls back
+	// to one.
+	zipPath := initTemplateFile
+	downloaded := false
+	if zipPath == "" && initDryRun && initTemplateURL == "" {
+		zipPath = cachedTemplatePath(aiTool, templateTag)
+	}
+	if zipPath != "" {
+		infoln(i18n.T("init.local_template", ui.ShortenPath(zipPath)))
+	} else if initTemplateURL != "" {
+		infoln(i18n.T("init.custom_template", initTemplateURL))
+		var err error
+		if zipPath, _, err = downloadTemplateZip(ctx, initTemplateURL); err != nil {
+			return err
+		}
+		if zipPath == "" {
+			return errInitInterrupted()
+		}
+		downloaded = true
+	} else {
+		var err error
+		zipPath, err = downloadTemplate(ctx, aiTool)
+		if err != nil {
+			cached := cachedTemplatePath(aiTool, templateTag)
+			if cached == "" {
+				return err
+			}
+			warnln(i18n.T("init.cached_template", err))
+			zipPath = cached
+		} else if zipPath == "" {
+			return errInitInterrupted()
+		} else {
+			downloaded = true
+		}
+	}
+
+	if initDryRun {
+		if downloaded {
+			defer os.Remove(zipPath)
+		}
+		return printInitPlan(zipPath, targetDir)
+	}
+
+	// Extract template (with config file merging)
+	infoln(i18n.T("init.extracting"))
+	if created, err := template.ExtractWithMerge(zipPath, targetDir); err != nil {
+		logger.Error("Failed to extract template", err)
+		// Clean up on error, removing only what this extraction created
+		if cleanupErr := template.CleanupPartial(created); cleanupErr != nil {
+			logger.Warn("Failed to clean up partial extraction: %v", cleanupErr)
+		}
+		return fmt.Errorf("failed to extract template: %w", err)
+	}
+
+	// Clean up downloaded ZIP; a local template file is left alone
+	if downloaded {
+		os.Remove(zipPath)
+	}
+	if ctx.Err() != nil {
+		return errInitInterrupted()
+	}
+
+	// Update AI tool config with GitHub token if needed
+	if initNoMCPConfig {
+		logger.Debug("Skipping AI tool config (--no-mcp-config)")
+	} else {
+		configureAITool(aiTool, targetDir)
+	}
+
+	// Install VS Code extension
+	if initNoExtension {
+		logger.Debug("Skipping extension install (--no-extension)")
+	} else {
+		installInitExtension(ctx)
+		if ctx.Err() != nil {
+			return errInitInterrupted()
+		}
+	}
+
+	// Success message; in quiet mode only this line
+	if quietMode {
+		fmt.Println(i18n.T("init.success"))
+		return nil
+	}
+	fmt.Printf("\n%s\n", i18n.T("init.success"))
+	if initOutput != "" && projectName != "." {
+		fmt.Println(i18n.T("init.project", projectName))
+	}
+	fmt.Println(i18n.T("init.directory", ui.ShortenPath(targetDir)))
+	fmt.Printf("%s\n\n", i18n.T("init.ai_tool", aiTool))
+
+	if cwd, err := os.Getwd(); err == nil && cwd != targetDir {
+		fmt.Println(i18n.T("init.next_steps"))
+		fmt.Printf("  cd %s\n", dirArg)
+	}
+
+	fmt.Println("\n" + i18n.T("init.enjoy"))
+
+	return nil
+}
+
+// errInitInterrupted is returned after init was cancelled by a signal, so
+// that the process exits with 130 like an interrupted upload
+func errInitInterrupted() error {
+	return clierrors.NewCLIError(context.Canceled, "init interrupted", clierrors.ExitInterrupted)
+}
+
+// downloadTemplate fetches the template for aiTool from the server, keeps a
+// copy in the template cache and returns the path of the downloaded zip. An
+// empty path with a nil error means the user cancelled.
+func downloadTemplate(ctx context.Context, aiTool string) (string, error) {
 	// Create API client
 	client, err := api.NewClient()
 	if err != nil {
 		logger.Error("Failed to create API client", err)
-		return fmt.Errorf("failed to create API client: %w", err)
+		return "", fmt.Errorf("failed to create API client: %w", err)
 	}
 
 	// Get template metadata
-	fmt.Println("📋 Fetching template...")
+	infoln(i18n.T("init.fetching"))
+	templateMeta, err := fetchTemplateMetadata(ctx, client, aiTool)
+	if err != nil || templateMeta == nil {
+		return "", err
+	}
+
+	// A presigned URL that is about to expire is fetched again up front, and
+	// one that turns out to have expired (403) once after the download fails
+	refetched := false
+	if templateMeta.ExpiresWithin(presignedURLMargin) {
+		logger.Warn("Template URL expires at %v, fetching a new one", templateMeta.ExpiresAt())
+		if templateMeta, err = fetchTemplateMetadata(ctx, client, aiTool); err != nil || templateMeta == nil {
+			return "", err
+		}
+		refetched = true
+	}
+
+	zipPath, checksum, err := downloadTemplateZip(ctx, templateMeta.DownloadURL)
+	if err != nil && errors.Is(err, template.ErrDownloadForbidden) && !refetched {
+		logger.Warn("Template download was denied (URL expired at %v?), fetching a new URL", templateMeta.ExpiresAt())
+		infoln(i18n.T("init.url_expired"))
+		if templateMeta, err = fetchTemplateMetadata(ctx, client, aiTool); err != nil || templateMeta == nil {
+			return "", err
+		}
+		zipPath, checksum, err = downloadTemplateZip(ctx, templateMeta.DownloadURL)
+	}
+	if err != nil || zipPath == "" {
+		return "", err
+	}
+
+	// Keep a copy in the template cache, recording the checksum computed
+	// during download so the cached file can be verified later
+	cacheTemplate(aiTool, templateTag, templateMeta.DownloadURL, zipPath, checksum)
+
+	return zipPath, nil
+}
+
+// presignedURLMargin is how long a presigned template URL must remain valid
+// for the download to be started with it
+const presignedURLMargin = 30 * time.Second
+
+// fetchTemplateMetadata gets the template metadata, including its presigned
+// download URL. Nil metadata with a nil error means the user cancelled.
+func fetchTemplateMetadata(ctx context.Context, client *api.Client, aiTool string) (*api.TemplateMetadata, error) {
 	templateMeta, err := client.GetProjectTemplate(ctx, aiTool, templateTag)
 	if err != nil {
 		if ctx.Err() == context.Canceled {
-			return nil // User cancelled
+			return nil, nil // User cancelled
 		}
 		logger.Error("Failed to get template", err)
-		return fmt.Errorf("failed to get template: %w", err)
+		return nil, fmt.Errorf("failed to get template: %w", err)
 	}
 
 	logger.Info("Template metadata received:")
@@ -137,92 +376,177 @@ func runInit(cmd *cobra.Command, args []string) error {
 	logger.Info("  DownloadURL: %s", templateMeta.DownloadURL)
 	logger.Info("  ExpiresIn: %d", templateMeta.ExpiresIn)
 	logger.Info("  Cached: %v", templateMeta.Cached)
+	return templateMeta, nil
+}
 
-	// Download template
-	fmt.Print("📥 Downloading...")
-	// Note: API doesn't provide size, so progress bar will show bytes downloaded
+// downloadTemplateZip downloads a template zip from downloadURL with a
+// progress bar and returns its path and checksum. An empty path with a nil
+// error means the user cancelled.
+func downloadTemplateZip(ctx context.Context, downloadURL string) (string, string, error) {
+	infof("%s", i18n.T("init.downloading"))
+	// Note: API doesn't provide size, so without Content-Length the progress
+	// bar falls back to a spinner showing bytes downloaded
 	var progressBar *ui.ProgressBar
 
-	zipPath, err := template.Download(templateMeta.DownloadURL, "", func(downloaded, total int64) {
-		if progressBar == nil && total > 0 {
-			progressBar = ui.NewProgressBar(total)
+	zipPath, checksum, err := template.Download(ctx, downloadURL, "", func(downloaded, total int64) {
+		if quietMode {
+			return
 		}
-		if progressBar != nil {
-			progressBar.Update(downloaded)
+		if progressBar == nil {
+			progressBar = ui.NewProgressBar(total)
 		}
+		progressBar.Update(downloaded)
 	})
 	if err != nil {
 		if ctx.Err() == context.Canceled {
-			return nil // User cancelled
+			return "", "", nil // User cancelled
 		}
 		logger.Error("Failed to download template", err)
-		return fmt.Errorf("failed to download template: %w", err)
+		return "", "", fmt.Errorf("failed to download template: %w", err)
 	}
 	if progressBar != nil {
 		progressBar.Finish()
 		fmt.Println()
 	}
+	return zipPath, checksum, nil
+}
 
-	// Extract template (with config file merging)
-	fmt.Println("📦 Extracting...")
-	if err := template.ExtractWithMerge(zipPath, targetDir); err != nil {
-		logger.Error("Failed to extract template", err)
-		// Clean up on error
-		template.CleanupPartial(targetDir)
-		return fmt.Errorf("failed to extract template: %w", err)
+// cachedTemplatePath returns the cached template zip for aiTool and tag if
+// one is fresh, or "" when the template has to be downloaded
+func cachedTemplatePath(aiTool, tag string) string {
+	cache, err := template.NewCache()
+	if err != nil {
+		logger.Debug("Template cache unavailable: %v", err)
+		return ""
 	}
+	if tag == "" {
+		tag = "default"
+	}
+	entry, err := cache.Get(aiTool, template.DefaultCacheTTL)
+	if err != nil || entry.Version != tag {
+		return ""
+	}
+	return entry.FilePath
+}
 
-	// Clean up downloaded ZIP
-	os.Remove(zipPath)
+// printInitPlan lists every file in the template with what extracting it
+// into targetDir would do: [new], [merge] or [overwrite]
+func printInitPlan(zipPath, targetDir string) error {
+	plan, err := template.PlanExtraction(zipPath, targetDir)
+	if err != nil {
+		return fmt.Errorf("failed to read template: %w", err)
+	}
 
-	// Update AI tool config with GitHub token if needed
-	fmt.Println("🔧 Configuring...")
+	counts := make(map[string]int)
+	fmt.Printf("\n%s\n", i18n.T("init.dry_run_header", len(plan), ui.ShortenPath(targetDir)))
+	for _, f := range plan {
+		counts[f.Action]++
+		fmt.Printf("  %-12s %s\n", "["+f.Action+"]", f.Name)
+	}
+	fmt.Printf("\n%s\n", i18n.T("init.dry_run_counts", counts[template.PlanNew], counts[template.PlanMerge], counts[template.PlanOverwrite]))
+	fmt.Println(i18n.T("init.dry_run_done"))
+	return nil
+}
+
+// configureAITool writes the stored GitHub token and MCP server endpoint into
+// the AI tool's config in targetDir. Failures are logged, not fatal.
+func configureAITool(aiTool, targetDir string) {
+	infoln(i18n.T("init.configuring"))
 	token, err := auth.LoadToken()
 	if err != nil {
 		logger.Warn("Failed to load GitHub token: %v", err)
-	} else if token.GitHubToken != "" {
-		// Load config to get MCP server endpoint
-		cfg, err := config.Load()
-		if err != nil {
-			logger.Warn("Failed to load config: %v", err)
-		} else {
-			if err := template.UpdateAIToolConfig(aiTool, targetDir, token.GitHubToken, cfg.MCPServerEndpoint); err != nil {
-				logger.Warn("Failed to update AI tool config: %v", err)
-			} else {
-				logger.Info("Successfully updated GitHub token in %s config", aiTool)
-			}
-		}
+		return
+	}
+	if token.GitHubToken == "" {
+		return
 	}
 
-	// Install VS Code extension
-	fmt.Println("📦 Installing VS Code extension...")
-	result := vscode.InstallExtension()
+	// Load config to get MCP server endpoint
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Warn("Failed to load config: %v", err)
+		return
+	}
+	if cfg.MCPServerEndpoint == "" {
+		// Staging has no well-known MCP endpoint; never fall back to production
+		logger.Warn("No MCP server endpoint for environment %s", config.ResolvedEnvironment(cfg.APIEndpoint))
+		warnln("  ⚠ Skipped the MCP config: set MOMORPH_STAGING_MCP_ENDPOINT (or MOMORPH_MCP_ENDPOINT) for staging")
+		return
+	}
+	if err := template.UpdateAIToolConfig(aiTool, targetDir, token.GitHubToken, cfg.MCPServerEndpoint); err != nil {
+		logger.Warn("Failed to update AI tool config: %v", err)
+		return
+	}
+	logger.Info("Successfully updated GitHub token in %s config", aiTool)
+}
+
+// installInitExtension installs the MoMorph VS Code extension, warning
+// rather than failing when VS Code is not available
+func installInitExtension(ctx context.Context) {
+	infoln(i18n.T("init.installing_extension"))
+	result := vscode.InstallExtension(ctx, initExtensionVersion)
 	if result.Error != nil {
 		logger.Warn("Extension installation failed: %v", result.Error)
 		fmt.Printf("  ⚠ %s\n", result.Message)
 	} else if result.Installed {
-		fmt.Printf("  ✓ %s\n", result.Message)
+		infof("  ✓ %s\n", result.Message)
 	} else {
 		fmt.Printf("  ⚠ %s\n", result.Message)
 	}
+}
 
-	// Success message
-	fmt.Printf("\n✓ Project initialized successfully!\n")
-	fmt.Printf("  Directory: %s\n", ui.ShortenPath(targetDir))
-	fmt.Printf("  AI tool: %s\n\n", aiTool)
-
-	if projectName != "." {
-		fmt.Println("-> Next steps:")
-		fmt.Printf("  cd %s\n", projectName)
+// listAITools prints the supported AI tools and where init writes each
+// tool's MCP server configuration
+func listAITools() error {
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	fmt.Println("\n  Enjoy building with MoMorph! 🚀")
-
+	fmt.Println("Supported AI tools (--ai):")
+	for _, tool := range config.AITools {
+		location := "no MCP config (not configured by init)"
+		if updater := template.GetConfigUpdater(tool); updater != nil {
+			path, err := updater.ConfigPath(projectDir)
+			switch {
+			case err != nil:
+				location = fmt.Sprintf("unknown (%v)", err)
+			case path == "":
+				location = "no MCP config file (configured by the MoMorph VSCode extension)"
+			default:
+				location = path
+			}
+		}
+		fmt.Printf("  %-10s %s\n", tool, location)
+	}
 	return nil
 }
 
-// checkDirectory checks if the directory exists and handles confirmation
-func checkDirectory(dirPath string) error {
+// cacheTemplate stores the downloaded template in the template cache. The
+// presigned query string is dropped from the recorded URL. Failures are only
+// logged; caching is not required for init to succeed.
+func cacheTemplate(aiTool, tag, downloadURL, zipPath, checksum string) {
+	cache, err := template.NewCache()
+	if err != nil {
+		logger.Warn("Failed to open template cache: %v", err)
+		return
+	}
+	if tag == "" {
+		tag = "default"
+	}
+	originalURL := downloadURL
+	if u, err := url.Parse(downloadURL); err == nil {
+		u.RawQuery = ""
+		originalURL = u.String()
+	}
+	if err := cache.PutFile(aiTool, tag, originalURL, zipPath, checksum); err != nil {
+		logger.Warn("Failed to cache template: %v", err)
+	}
+}
+
+// checkDirectory checks if the directory exists and handles confirmation.
+// With force set, a non-empty directory is accepted without prompting.
+func checkDirectory(dirPath string, force bool) error {
 	// Check if directory exists
 	info, err := os.Stat(dirPath)
 	if os.IsNotExist(err) {
@@ -245,7 +569,11 @@ func checkDirectory(dirPath string) error {
 	}
 
 	// If directory is not empty, ask for confirmation
-	if len(entries) > 0 {
+	if len(entries) > 0 && !force {
+		if !ui.IsInteractive() {
+			return fmt.Errorf("directory is not empty: %s (use --force to initialize into it without a prompt)", dirPath)
+		}
+
 		confirm, err := ui.ConfirmOverwrite(dirPath)
 		if err != nil {
 			return fmt.Errorf("failed to get confirmation: %w", err)
@@ -8,24 +8,30 @@ import (
 	"os/exec"
 	"os/signal"
 	"runtime"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/momorph/cli/internal/auth"
+	"github.com/momorph/cli/internal/i18n"
 	"github.com/momorph/cli/internal/logger"
 	"github.com/spf13/cobra"
 )
 
+var loginNoBrowser bool
+
 var loginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Authenticate with MoMorph using GitHub",
 	Example: `  momorph login              # Start authentication flow
-  momorph login --debug      # Start with debug logging enabled`,
+  momorph login --debug      # Start with debug logging enabled
+  momorph login --no-browser # Print the URL instead of opening a browser (SSH, WSL)`,
 	RunE: runLogin,
 }
 
 func init() {
+	loginCmd.Flags().BoolVar(&loginNoBrowser, "no-browser", false, "Don't open a browser; print the verification URL and code instead")
 	rootCmd.AddCommand(loginCmd)
 }
 
@@ -40,20 +46,21 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		<-sigChan
-		fmt.Println("\n\n✗ Login cancelled by user")
+		fmt.Println("\n\n" + i18n.T("login.cancelled"))
 		cancel()
 		os.Exit(0)
 	}()
 
 	// Check if already authenticated
 	if auth.IsAuthenticated() {
-		fmt.Println("✓ Already authenticated. Use 'momorph logout' to sign out.")
+		fmt.Println(i18n.T("login.already_authenticated"))
 		return nil
 	}
 
 	// Request device code
-	fmt.Println("🔑 Requesting device code from GitHub")
-	deviceCode, err := auth.RequestDeviceCode(ctx)
+	infoln(i18n.T("login.requesting_code"))
+	scope := auth.RequestedScope()
+	deviceCode, err := auth.RequestDeviceCode(ctx, scope)
 	if err != nil {
 		logger.Error("Failed to request device code", err)
 		return fmt.Errorf("failed to request device code: %w", err)
@@ -66,28 +73,38 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		Background(lipgloss.Color("235")).
 		Padding(0, 1)
 
-	fmt.Printf("\n1. Press Enter to open your browser: %s\n", lipgloss.NewStyle().Underline(true).Render(deviceCode.VerificationURI))
-	fmt.Printf("2. Enter this code: %s\n", codeStyle.Render(deviceCode.UserCode))
-	fmt.Printf("\n%s", lipgloss.NewStyle().Faint(true).Render("Press Enter to continue..."))
-
-	// Wait for user to press enter
-	reader := bufio.NewReader(os.Stdin)
-	reader.ReadString('\n')
+	verificationURI := lipgloss.NewStyle().Underline(true).Render(deviceCode.VerificationURI)
 
-	// Open browser
-	fmt.Println("\n🌐 Opening browser...")
-	if err := openBrowser(deviceCode.VerificationURI); err != nil {
-		logger.Warn("Failed to open browser: %v", err)
-		fmt.Printf("⚠  Could not open browser automatically. Please visit: %s\n\n", deviceCode.VerificationURI)
+	headless := !loginNoBrowser && isHeadlessSession()
+	if loginNoBrowser || headless {
+		// No local browser: show the URL and code, then go straight to polling
+		if headless {
+			fmt.Printf("\n%s\n", i18n.T("login.headless_detected"))
+		}
+		fmt.Printf("\n%s\n", i18n.T("login.step_visit", verificationURI))
+		fmt.Printf("%s\n\n", i18n.T("login.step_enter_code", codeStyle.Render(deviceCode.UserCode)))
+	} else {
+		fmt.Printf("\n%s\n", i18n.T("login.step_open_browser", verificationURI))
+		fmt.Println(i18n.T("login.step_enter_code", codeStyle.Render(deviceCode.UserCode)))
+		fmt.Printf("\n%s", lipgloss.NewStyle().Faint(true).Render(i18n.T("login.press_enter")))
+
+		// Wait for user to press enter
+		reader := bufio.NewReader(os.Stdin)
+		reader.ReadString('\n')
+
+		// Open browser
+		infoln("\n" + i18n.T("login.opening_browser"))
+		if err := openBrowser(deviceCode.VerificationURI); err != nil {
+			logger.Warn("Failed to open browser: %v", err)
+			fmt.Printf("%s\n\n", i18n.T("login.browser_failed", deviceCode.VerificationURI))
+		}
 	}
 
-	// Poll for token
-	fmt.Println("⏳ Waiting for authorization...")
-
-	pollCtx, pollCancel := context.WithTimeout(ctx, time.Duration(deviceCode.ExpiresIn)*time.Second)
-	defer pollCancel()
-
-	tokenResp, err := auth.PollForToken(pollCtx, deviceCode.DeviceCode, deviceCode.Interval)
+	// Poll for token, showing how long the code stays valid
+	tokenResp, err := auth.PollForToken(ctx, deviceCode, func(remaining time.Duration) {
+		fmt.Printf("\r%s ", i18n.T("login.waiting_remaining", formatRemaining(remaining)))
+	})
+	fmt.Println()
 	if err != nil {
 		if ctx.Err() == context.Canceled {
 			return nil // User cancelled
@@ -96,8 +113,14 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get GitHub token: %w", err)
 	}
 
+	// The user can deselect scopes on GitHub's authorization page
+	if missing := auth.MissingScopes(scope, tokenResp.Scope); len(missing) > 0 {
+		logger.Warn("GitHub granted scopes %q, missing %v", tokenResp.Scope, missing)
+		fmt.Println(i18n.T("login.scope_missing", strings.Join(missing, ", ")))
+	}
+
 	// Get user info to display
-	fmt.Println("👤 Fetching user information...")
+	infoln(i18n.T("login.fetching_user"))
 	moMorphUser, err := auth.GetMoMorphUser(ctx, tokenResp.AccessToken)
 	if err != nil {
 		logger.Error("Failed to get user info", err)
@@ -105,18 +128,41 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	}
 
 	// Save GitHub access token
-	fmt.Println("💾 Saving credentials...")
-	if err := auth.SaveToken(tokenResp.AccessToken); err != nil {
+	infoln(i18n.T("login.saving"))
+	token := &auth.AuthToken{
+		GitHubToken:  tokenResp.AccessToken,
+		GitHubScopes: auth.ParseScopes(tokenResp.Scope),
+	}
+	token.SetUser(moMorphUser)
+	if err := auth.SaveToken(token); err != nil {
 		logger.Error("Failed to save token", err)
 		return fmt.Errorf("failed to save token: %w", err)
 	}
 
-	fmt.Println("\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true).Render("✓ Successfully authenticated!"))
-	fmt.Printf("  Logged in as: %s\n", lipgloss.NewStyle().Bold(true).Render(maskEmail(moMorphUser.Email)))
+	fmt.Println("\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true).Render(i18n.T("login.success")))
+	fmt.Println(i18n.T("login.logged_in_as", lipgloss.NewStyle().Bold(true).Render(maskEmail(moMorphUser.Email))))
 
 	return nil
 }
 
+// formatRemaining formats a duration as m:ss for the login countdown
+func formatRemaining(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// isHeadlessSession reports whether there is likely no local browser to
+// open: an SSH session, or Linux without a graphical display
+func isHeadlessSession() bool {
+	if os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != "" {
+		return true
+	}
+	if runtime.GOOS == "linux" && os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		return true
+	}
+	return false
+}
+
 // openBrowser opens the specified URL in the default browser
 func openBrowser(url string) error {
 	var cmd *exec.Cmd
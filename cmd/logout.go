@@ -13,18 +13,22 @@ import (
 
 var (
 	forceLogout bool
+	// logoutAll also removes the token baked into global MCP configs
+	logoutAll bool
 )
 
 var logoutCmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Log out and delete stored credentials",
 	Example: `  momorph logout            # Log out with confirmation prompt
-  momorph logout --force    # Log out without confirmation`,
+  momorph logout --force    # Log out without confirmation
+  momorph logout --all      # Also remove the MoMorph MCP server from Cursor/Windsurf configs`,
 	RunE: runLogout,
 }
 
 func init() {
 	logoutCmd.Flags().BoolVar(&forceLogout, "force", false, "Skip confirmation prompt")
+	logoutCmd.Flags().BoolVar(&logoutAll, "all", false, "Also remove the MoMorph MCP server (and its GitHub token) from global Cursor and Windsurf configs and their backups")
 	rootCmd.AddCommand(logoutCmd)
 }
 
@@ -32,6 +36,9 @@ func runLogout(cmd *cobra.Command, args []string) error {
 	// Check if authenticated
 	if !auth.IsAuthenticated() {
 		fmt.Println("Not currently authenticated")
+		if logoutAll {
+			return cleanMCPConfigs()
+		}
 		return nil
 	}
 
@@ -61,5 +68,8 @@ func runLogout(cmd *cobra.Command, args []string) error {
 	logger.Info("User logged out")
 	fmt.Println("✓ Successfully signed out")
 
+	if logoutAll {
+		return cleanMCPConfigs()
+	}
 	return nil
 }
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/momorph/cli/internal/auth"
+	"github.com/momorph/cli/internal/config"
+	"github.com/momorph/cli/internal/i18n"
+	"github.com/momorph/cli/internal/logger"
+	"github.com/momorph/cli/internal/template"
+	"github.com/momorph/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Manage the MoMorph MCP server in AI tool configs",
+}
+
+var mcpCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove the MoMorph MCP server from global Cursor and Windsurf configs",
+	Long: `Remove the "momorph" MCP server, including the GitHub token stored in its
+headers, from ~/.cursor/mcp.json and ~/.codeium/windsurf/mcp_config.json.
+Other servers are left intact. No backup is written, since it would keep the
+token, and the backups (.bak) earlier updates left next to these files are
+deleted if they contain a GitHub token.
+
+Project files such as .mcp.json are not changed.`,
+	Example: `  momorph mcp clean
+  momorph logout --all   # Log out and clean in one step`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cleanMCPConfigs()
+	},
+}
+
+// mcpSyncAI selects the tools to sync: one of config.AITools, "all", or
+// empty for the tools whose config already contains the MoMorph server
+var mcpSyncAI string
+
+var mcpSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Write the current GitHub token and MCP endpoint into AI tool configs",
+	Long: `Write the GitHub token of the current login and the MCP server endpoint
+into the MCP configs of the project in the current directory (.mcp.json) and
+the global Cursor and Windsurf configs, as init does. Run it after logging in
+again so MCP calls don't keep using the old token.
+
+Without --ai, only configs that already contain the MoMorph server are
+updated.`,
+	Example: `  momorph mcp sync              # Refresh every config that has the MoMorph server
+  momorph mcp sync --ai cursor  # Configure Cursor only
+  momorph mcp sync --ai all     # Configure every supported tool`,
+	Args: cobra.NoArgs,
+	RunE: runMCPSync,
+}
+
+func init() {
+	mcpSyncCmd.Flags().StringVar(&mcpSyncAI, "ai", "", "AI tool to sync ("+strings.Join(config.AITools, ", ")+", or all)")
+	mcpSyncCmd.RegisterFlagCompletionFunc("ai", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return append(append([]string{}, config.AITools...), "all"), cobra.ShellCompDirectiveNoFileComp
+	})
+	mcpCmd.AddCommand(mcpSyncCmd)
+	mcpCmd.AddCommand(mcpCleanCmd)
+	rootCmd.AddCommand(mcpCmd)
+}
+
+func runMCPSync(cmd *cobra.Command, args []string) error {
+	if mcpSyncAI != "" && mcpSyncAI != "all" && !config.IsValidAITool(mcpSyncAI) {
+		return fmt.Errorf("invalid AI tool: %s (must be one of: %s, or all)", mcpSyncAI, strings.Join(config.AITools, ", "))
+	}
+
+	token, err := auth.LoadToken()
+	if err != nil || token.GitHubToken == "" {
+		fmt.Println(i18n.T("auth.not_authenticated"))
+		return errNotAuthenticated()
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.MCPServerEndpoint == "" {
+		return fmt.Errorf("no MCP server endpoint for this environment: set MOMORPH_STAGING_MCP_ENDPOINT (or MOMORPH_MCP_ENDPOINT)")
+	}
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	tools := config.AITools
+	if mcpSyncAI != "" && mcpSyncAI != "all" {
+		tools = []string{mcpSyncAI}
+	}
+
+	synced, failed := 0, 0
+	for _, tool := range tools {
+		updater := template.GetConfigUpdater(tool)
+		if updater == nil {
+			continue
+		}
+		path, err := updater.ConfigPath(projectDir)
+		if err != nil {
+			return err
+		}
+		if path == "" {
+			// Copilot gets its MCP server from the VS Code extension
+			if mcpSyncAI == tool {
+				infof("%s: the MCP server is provided by the VS Code extension, nothing to sync\n", tool)
+			}
+			continue
+		}
+		if mcpSyncAI == "" && !template.HasMCPServer(path) {
+			continue
+		}
+
+		if err := template.UpdateAIToolConfig(tool, projectDir, token.GitHubToken, cfg.MCPServerEndpoint); err != nil {
+			logger.Warn("Failed to sync %s config: %v", tool, err)
+			warnln(fmt.Sprintf("⚠ %s: %v", tool, err))
+			failed++
+			continue
+		}
+		if !template.HasMCPServer(path) {
+			// Project configs are only updated, never created
+			infof("%s: %s has no MoMorph server, run 'momorph init' to add it\n", tool, ui.ShortenPath(path))
+			continue
+		}
+		infof("✓ %s: %s\n", tool, ui.ShortenPath(path))
+		synced++
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to sync %d MCP config(s)", failed)
+	}
+	if synced == 0 && mcpSyncAI == "" {
+		infoln("No MCP config contains the MoMorph server; use --ai to configure a tool")
+	}
+	return nil
+}
+
+// cleanMCPConfigs removes the momorph server from every global MCP config,
+// reporting each file it changed. It keeps going after a failure and returns
+// an error if any file could not be cleaned.
+func cleanMCPConfigs() error {
+	paths, err := template.GlobalMCPConfigPaths()
+	if err != nil {
+		return err
+	}
+
+	removed, failed := 0, 0
+	for _, path := range paths {
+		ok, err := template.RemoveMCPServer(path)
+		if err != nil {
+			logger.Warn("Failed to clean %s: %v", path, err)
+			warnln(fmt.Sprintf("⚠ Could not clean %s: %v", ui.ShortenPath(path), err))
+			failed++
+			continue
+		}
+		if ok {
+			infof("✓ Removed the MoMorph MCP server from %s\n", ui.ShortenPath(path))
+			removed++
+		}
+
+		backups, err := template.RemoveMCPServerBackups(path)
+		for _, backup := range backups {
+			infof("✓ Deleted backup %s, which contained a GitHub token\n", ui.ShortenPath(backup))
+			removed++
+		}
+		if err != nil {
+			logger.Warn("Failed to clean backups of %s: %v", path, err)
+			warnln(fmt.Sprintf("⚠ Could not clean backups of %s: %v", ui.ShortenPath(path), err))
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to clean %d MCP config file(s)", failed)
+	}
+	if removed == 0 {
+		infoln("No global MCP config or backup contains the MoMorph server")
+	}
+	return nil
+}
@@ -23,9 +23,21 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/momorph/cli/internal/config"
+	clierrors "github.com/momorph/cli/internal/errors"
+	"github.com/momorph/cli/internal/i18n"
 	"github.com/momorph/cli/internal/logger"
+	"github.com/momorph/cli/internal/update"
+	"github.com/momorph/cli/internal/utils"
+	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
 )
 
@@ -33,6 +45,14 @@ var (
 	// Global flags
 	debugMode bool
 	quietMode bool
+	langFlag  string
+	logFormat string
+	endpoint  string
+	envFlag   string
+	noColor   bool
+	// HTTP tuning flags
+	httpTimeout    time.Duration
+	httpMaxRetries int
 	// Global context for graceful shutdown
 	globalCtx context.Context
 )
@@ -44,19 +64,97 @@ var rootCmd = &cobra.Command{
 	Example: `  momorph login                         # Log in to MoMorph platform
   momorph init my-project --ai=copilot  # Initialize a new MoMorph project`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Plain output everywhere, including lipgloss-styled text
+		if !isColorEnabled() {
+			lipgloss.SetColorProfile(termenv.Ascii)
+		}
+
+		// Select message language: --lang wins over environment detection
+		if langFlag != "" {
+			i18n.SetLanguage(langFlag)
+		} else {
+			i18n.SetLanguage(i18n.DetectLanguage())
+		}
+
+		// Apply HTTP tuning flags to every client created from here on
+		if httpTimeout <= 0 {
+			return fmt.Errorf("--timeout must be greater than 0")
+		}
+		if httpMaxRetries < 0 {
+			return fmt.Errorf("--max-retries must not be negative")
+		}
+		httpConfig := utils.DefaultHTTPConfig()
+		httpConfig.Timeout = httpTimeout
+		httpConfig.MaxRetries = httpMaxRetries
+		httpConfig.Debug = debugMode // sanitized request/response dumps
+		utils.SetDefaultHTTPConfig(httpConfig)
+
+		// Select production or staging for this invocation only
+		if envFlag != "" {
+			env, err := config.NormalizeEnvironment(envFlag)
+			if err != nil {
+				return fmt.Errorf("--env: %w", err)
+			}
+			if endpoint == "" {
+				envEndpoint, err := config.EnvironmentEndpoint(env)
+				if err != nil {
+					return fmt.Errorf("--env: %w", err)
+				}
+				if err := config.ValidateEndpoint(envEndpoint); err != nil {
+					return fmt.Errorf("--env: %w", err)
+				}
+			}
+			config.SetEnvironmentOverride(env)
+		}
+
+		// Point API clients at another server for this invocation only
+		if endpoint != "" {
+			if err := config.ValidateEndpoint(endpoint); err != nil {
+				return fmt.Errorf("--endpoint: %w", err)
+			}
+			config.SetAPIEndpointOverride(endpoint)
+		}
+
 		// Initialize logger before any command runs
-		return logger.Init(debugMode)
+		// Priority for the format: --log-format > MOMORPH_LOG_FORMAT
+		format := logFormat
+		if format == "" {
+			format = os.Getenv("MOMORPH_LOG_FORMAT")
+		}
+		logOpts := logger.Options{Debug: debugMode, Format: format}
+		if cfg, err := config.Load(); err == nil {
+			logOpts.Level = cfg.LogLevel
+		}
+		if err := logger.Init(logOpts); err != nil {
+			return err
+		}
+		for _, change := range config.MigrationLog() {
+			logger.Debug("Config migration: %s", change)
+		}
+
+		// Clean up the old binary a previous self-update could not delete (Windows)
+		update.RemoveStaleBackup()
+		return nil
 	},
 	// Enable command suggestions for typos
 	SuggestionsMinimumDistance: 2,
 	// Don't show usage when command returns an error
 	SilenceUsage: true,
+	// Errors are printed by Execute, which skips those already shown
+	SilenceErrors: true,
 }
 
 func init() {
 	// Global flags
 	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "Enable debug logging")
 	rootCmd.PersistentFlags().BoolVarP(&quietMode, "quiet", "q", false, "Suppress non-error output")
+	rootCmd.PersistentFlags().DurationVar(&httpTimeout, "timeout", utils.DefaultHTTPConfig().Timeout, "HTTP request timeout (e.g. 30s, 2m)")
+	rootCmd.PersistentFlags().IntVar(&httpMaxRetries, "max-retries", utils.DefaultHTTPConfig().MaxRetries, "Maximum retries for failed HTTP requests")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "", "Log output format on stderr: console or json (default: stderr logs only with --debug)")
+	rootCmd.PersistentFlags().StringVar(&endpoint, "endpoint", "", "MoMorph API endpoint for this invocation (https:// URL, or http:// for localhost), overrides MOMORPH_API_ENDPOINT and the config file")
+	rootCmd.PersistentFlags().StringVar(&envFlag, "env", "", "Environment for this invocation: production or staging (staging uses MOMORPH_STAGING_API_ENDPOINT), overrides MOMORPH_ENV")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also set by NO_COLOR or TERM=dumb)")
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "Output language (en, vi, ja); defaults to MOMORPH_LANG or LANG")
 
 	// Disable default completion command (we have a custom one in completion.go)
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
@@ -68,10 +166,44 @@ func init() {
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	err := rootCmd.Execute()
+	cmd, err := rootCmd.ExecuteC()
 	if err != nil {
-		os.Exit(1)
+		printError(cmd, err)
+		os.Exit(int(exitCodeFor(err)))
+	}
+}
+
+// printError prints a command error as cobra would, unless the command
+// already showed it to the user
+func printError(cmd *cobra.Command, err error) {
+	var cliErr *clierrors.CLIError
+	if errors.As(err, &cliErr) && cliErr.Reported {
+		return
+	}
+	cmd.PrintErrln(cmd.ErrPrefix(), err.Error())
+	if cmd == rootCmd {
+		// No command ran, e.g. an unknown one was given
+		cmd.PrintErrf("Run '%v --help' for usage.\n", cmd.CommandPath())
+	}
+}
+
+// exitCodeFor maps a command error to the process exit code. Commands return
+// a CLIError to choose the code; otherwise network failures exit with
+// ExitNetworkError and everything else with ExitError. Network failures are
+// told apart by their error types: checking for net.Error would also match
+// the syscall.Errno behind a missing local file.
+func exitCodeFor(err error) clierrors.ExitCode {
+	var cliErr *clierrors.CLIError
+	if errors.As(err, &cliErr) {
+		return cliErr.ExitCode
+	}
+	var urlErr *url.Error
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	if errors.As(err, &urlErr) || errors.As(err, &opErr) || errors.As(err, &dnsErr) {
+		return clierrors.ExitNetworkError
 	}
+	return clierrors.ExitError
 }
 
 // GetDebugMode returns the current debug mode setting
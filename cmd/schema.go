@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/momorph/cli/internal/upload"
+	"github.com/spf13/cobra"
+)
+
+var schemaJSON bool
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema <specs|testcases>",
+	Short: "Show the CSV columns accepted by the upload commands",
+	Long: `Show the CSV columns accepted by 'momorph upload specs' or
+'momorph upload testcases': the field each column is stored in, whether it is
+required, its maximum length and its accepted values.
+
+Required "completed" means the column must be filled for a spec to be
+uploaded with status completed.`,
+	Example: `  momorph schema specs
+  momorph schema testcases --json`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"specs", "testcases"},
+	RunE:      runSchema,
+}
+
+func init() {
+	schemaCmd.Flags().BoolVar(&schemaJSON, "json", false, "Output as JSON")
+	rootCmd.AddCommand(schemaCmd)
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	var columns []upload.ColumnSchema
+	switch args[0] {
+	case "specs":
+		columns = upload.SpecColumns()
+	case "testcases":
+		columns = upload.TestcaseColumns()
+	default:
+		return fmt.Errorf("unknown schema %q (must be one of: specs, testcases)", args[0])
+	}
+
+	if schemaJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(columns)
+	}
+
+	rows := make([][]string, 0, len(columns))
+	for _, c := range columns {
+		maxLength := ""
+		if c.MaxLength > 0 {
+			maxLength = strconv.Itoa(c.MaxLength)
+		}
+		values := strings.Join(c.Values, ", ")
+		if c.Note != "" {
+			if values != "" {
+				values += "; "
+			}
+			values += c.Note
+		}
+		rows = append(rows, []string{c.Column, c.Field, c.Type, c.Required, maxLength, values})
+	}
+
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		Headers("Column", "Field", "Type", "Required", "Max", "Values / Notes").
+		Rows(rows...)
+	fmt.Println(t)
+	return nil
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/momorph/cli/internal/upload"
+	"github.com/spf13/cobra"
+)
+
+var specsCmd = &cobra.Command{
+	Use:   "specs",
+	Short: "Work with spec definitions",
+}
+
+var specsValidateSchemaCmd = &cobra.Command{
+	Use:   "validate-schema",
+	Short: "Print the spec validation rules as JSON",
+	Long: `Print the rules used to validate specs before upload as JSON: accepted
+statuses and types, maximum field lengths, which item types need a data type
+or length when completed, and the CSV columns (see 'momorph schema specs').
+
+Tools such as the VS Code extension can read this instead of duplicating the
+rules, so their validation stays in sync with the CLI.`,
+	Example: `  momorph specs validate-schema
+  momorph specs validate-schema > spec-rules.json`,
+	Args: cobra.NoArgs,
+	RunE: runSpecsValidateSchema,
+}
+
+func init() {
+	specsCmd.AddCommand(specsValidateSchemaCmd)
+	rootCmd.AddCommand(specsCmd)
+}
+
+func runSpecsValidateSchema(cmd *cobra.Command, args []string) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(upload.SpecRules())
+}
@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/charmbracelet/lipgloss"
+	clierrors "github.com/momorph/cli/internal/errors"
+	"github.com/momorph/cli/internal/i18n"
 	"github.com/momorph/cli/internal/logger"
 	"github.com/momorph/cli/internal/ui"
 	"github.com/momorph/cli/internal/update"
@@ -13,19 +16,25 @@ import (
 )
 
 var (
-	checkOnly bool
+	checkOnly     bool
+	targetVersion string
+	updateForce   bool
 )
 
 var updateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update MoMorph CLI to the latest version",
 	Example: `  momorph update           # Check and install update
-  momorph update --check   # Only check for updates`,
+  momorph update --check   # Only check for updates
+  momorph update --version 1.2.3   # Install a specific version (also downgrades)
+  momorph update --force   # Replace the binary even if a package manager installed it or it cannot be verified`,
 	RunE: runUpdate,
 }
 
 func init() {
 	updateCmd.Flags().BoolVar(&checkOnly, "check", false, "Only check for updates, don't install")
+	updateCmd.Flags().StringVar(&targetVersion, "version", "", "Install a specific version (e.g. 1.2.3) instead of the latest")
+	updateCmd.Flags().BoolVar(&updateForce, "force", false, "Update in place even if the CLI was installed by a package manager, or without a checksum to verify the download")
 	rootCmd.AddCommand(updateCmd)
 }
 
@@ -33,90 +42,158 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
 	currentVersion := version.Version
-	fmt.Printf("Current version: %s\n\n", currentVersion)
-
-	// Check for latest release
-	fmt.Println("🔍 Checking for updates...")
-	release, err := update.GetLatestRelease(ctx)
+	infof("%s\n\n", i18n.T("update.current_version", currentVersion))
+
+	// Check for the latest release, or the requested one
+	var release *update.Release
+	var err error
+	if targetVersion != "" {
+		infoln(i18n.T("update.looking_up", targetVersion))
+		release, err = update.GetReleaseByTag(ctx, targetVersion)
+	} else {
+		infoln(i18n.T("update.checking"))
+		release, err = update.GetLatestRelease(ctx)
+	}
+	if errors.Is(err, update.ErrNoReleases) {
+		// Nothing has been published yet, which is not a failure
+		fmt.Println(i18n.T("update.no_releases"))
+		return nil
+	}
 	if err != nil {
 		logger.Error("Failed to check for updates", err)
-		fmt.Println("\n✗ Failed to check for updates")
-		fmt.Println("  Please check your internet connection and try again.")
-		return nil
+		fmt.Println("\n" + i18n.T("update.check_failed"))
+		if targetVersion != "" {
+			fmt.Printf("  %v\n", err)
+			return clierrors.NewError(err, "failed to look up version "+targetVersion)
+		}
+		fmt.Println(i18n.T("update.check_connection"))
+		return clierrors.NewNetworkError(err, "failed to check for updates")
 	}
 
 	latestVersion := release.GetVersion()
-	logger.Debug("Latest version: %s", latestVersion)
+	logger.Debug("Target version: %s", latestVersion)
 
-	// Compare versions
+	// Compare versions; a pinned version is installed even if it is older
 	comparison := update.CompareVersions(currentVersion, latestVersion)
-
-	if comparison >= 0 {
+	downgrade := targetVersion != "" && comparison > 0
+
+	if comparison == 0 || (comparison > 0 && !downgrade) {
+		if targetVersion != "" {
+			fmt.Println(lipgloss.NewStyle().
+				Foreground(lipgloss.Color("42")).
+				Bold(true).
+				Render(i18n.T("update.already_on", latestVersion)))
+			return nil
+		}
 		fmt.Println(lipgloss.NewStyle().
 			Foreground(lipgloss.Color("42")).
 			Bold(true).
-			Render("✓ Already on the latest version!"))
+			Render(i18n.T("update.already_latest")))
 		return nil
 	}
 
-	// Update available
+	// Update (or downgrade) available
+	label := i18n.T("update.available")
+	if downgrade {
+		label = i18n.T("update.downgrade")
+	}
 	fmt.Printf("\n%s %s → %s\n",
-		lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("⚡ Update available:"),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(label),
 		currentVersion,
 		lipgloss.NewStyle().Bold(true).Render(latestVersion))
 
-	fmt.Printf("   Release notes: %s\n\n", release.HTMLURL)
+	fmt.Printf("%s\n\n", i18n.T("update.release_notes", release.HTMLURL))
+
+	// A binary owned by a package manager is updated through it, so its
+	// records stay in sync with the files on disk
+	if pm := update.DetectPackageManager(); pm != nil && !updateForce {
+		fmt.Printf("%s\n\n  %s\n\n", i18n.T("update.package_manager", pm.Name), pm.UpgradeCommand)
+		fmt.Println(i18n.T("update.package_manager_force"))
+		return nil
+	}
 
 	// If only checking, stop here
 	if checkOnly {
-		fmt.Println("Run 'momorph update' (without --check) to install the update.")
+		fmt.Println(i18n.T("update.run_to_install"))
 		return nil
 	}
 
+	// Make sure the binary can be replaced before asking to download
+	if err := update.CheckWritable(); err != nil {
+		logger.Error("Current binary is not writable", err)
+		fmt.Println("\n" + i18n.T("update.not_writable"))
+		fmt.Printf("  %v\n", err)
+		return clierrors.NewError(err, "update failed")
+	}
+
 	// Get platform-specific asset
 	asset, err := release.GetAssetForPlatform()
 	if err != nil {
 		logger.Error("Failed to find release asset", err)
-		fmt.Println("\n✗ No release available for your platform")
-		fmt.Println("  Please download manually from: " + release.HTMLURL)
+		fmt.Println("\n" + i18n.T("update.no_platform_asset"))
+		fmt.Println(i18n.T("update.download_manually", release.HTMLURL))
 		return nil
 	}
 
+	// Look up the expected checksum so the download can be verified. A
+	// binary that cannot be verified is only installed with --force.
+	if err := release.ResolveChecksum(ctx, asset); err != nil {
+		logger.Warn("Checksum unavailable: %v", err)
+		fmt.Println(i18n.T("update.unverified", err))
+		if !updateForce {
+			fmt.Println(i18n.T("update.unverified_force"))
+			return clierrors.NewError(err, "update not installed: the download cannot be verified")
+		}
+	}
+
 	// Confirm update
-	confirm, err := ui.ConfirmUpdate(currentVersion, latestVersion)
+	var confirm bool
+	if downgrade {
+		confirm, err = ui.ConfirmDowngrade(currentVersion, latestVersion)
+	} else {
+		confirm, err = ui.ConfirmUpdate(currentVersion, latestVersion)
+	}
 	if err != nil {
 		logger.Error("Failed to get confirmation", err)
 		return nil
 	}
 	if !confirm {
-		fmt.Println("Update cancelled")
+		fmt.Println(i18n.T("update.cancelled"))
 		return nil
 	}
 
 	logger.Debug("Downloading: %s", asset.Name)
 
 	// Download and install
-	fmt.Printf("\n📥 Downloading %s...\n", asset.Name)
-	progressBar := ui.NewProgressBar(asset.Size)
+	infoln("\n" + i18n.T("update.downloading", asset.Name))
+	var progress update.ProgressCallback
+	var progressBar *ui.ProgressBar
+	if !quietMode {
+		progressBar = ui.NewProgressBar(asset.Size)
+		progress = func(downloaded, total int64) {
+			progressBar.Update(downloaded)
+		}
+	}
 
-	installedPath, err := update.DownloadAndReplace(ctx, asset, func(downloaded, total int64) {
-		progressBar.Update(downloaded)
-	})
-	progressBar.Finish()
+	installedPath, err := update.DownloadAndReplace(ctx, asset, progress)
+	if progressBar != nil {
+		progressBar.Finish()
+	}
 
 	if err != nil {
 		logger.Error("Failed to update", err)
-		fmt.Println("\n✗ Failed to update")
-		fmt.Println("  Please try again or download manually from: " + release.HTMLURL)
-		return nil
+		fmt.Println("\n" + i18n.T("update.failed"))
+		fmt.Printf("  %v\n", err)
+		fmt.Println(i18n.T("update.retry_or_download", release.HTMLURL))
+		return clierrors.NewError(err, "update failed")
 	}
 
 	fmt.Println(lipgloss.NewStyle().
 		Foreground(lipgloss.Color("42")).
 		Bold(true).
-		Render("\n✓ Updated successfully!"))
+		Render("\n" + i18n.T("update.success")))
 
-	fmt.Printf("  Binary: %s\n", installedPath)
+	fmt.Println(i18n.T("update.binary", installedPath))
 
 	return nil
 }
@@ -1,9 +1,33 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	clierrors "github.com/momorph/cli/internal/errors"
+	"github.com/momorph/cli/internal/i18n"
+	"github.com/momorph/cli/internal/logger"
+	"github.com/momorph/cli/internal/upload"
 	"github.com/spf13/cobra"
 )
 
+var (
+	// uploadRateLimit caps API requests per second during uploads (0 = unlimited)
+	uploadRateLimit float64
+	// uploadOutput selects the summary format: text or json
+	uploadOutput string
+	// uploadResume skips the files an earlier, interrupted run recorded as
+	// completed
+	uploadResume bool
+	// uploadVerbose prints an HTTP timing summary at the end of the run
+	uploadVerbose bool
+)
+
 var uploadCmd = &cobra.Command{
 	Use:   "upload",
 	Short: "Upload data to MoMorph server",
@@ -19,5 +43,122 @@ Example:
 }
 
 func init() {
+	uploadCmd.PersistentFlags().Float64Var(&uploadRateLimit, "rate-limit", 0, "Maximum API requests per second (0 = unlimited)")
+	uploadCmd.PersistentFlags().StringVar(&uploadOutput, "output", "text", "Summary format: text, or json for a single JSON object on stdout")
+	uploadCmd.PersistentFlags().BoolVar(&uploadResume, "resume", false, "Skip files an interrupted earlier run already uploaded (if unchanged)")
+	uploadCmd.PersistentFlags().BoolVar(&uploadVerbose, "verbose", false, "Print an HTTP timing summary (requests, network time, slowest endpoints) at the end; also shown with --debug")
 	rootCmd.AddCommand(uploadCmd)
 }
+
+// validateUploadOutput checks --output. JSON output only covers real
+// uploads, so modes that print their own report (passed as flag names that
+// are set) are rejected with it.
+func validateUploadOutput(textOnlyFlags ...string) error {
+	switch uploadOutput {
+	case "text":
+		return nil
+	case "json":
+		if len(textOnlyFlags) > 0 {
+			return fmt.Errorf("--output json cannot be combined with --%s", textOnlyFlags[0])
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid --output %q (must be one of: text, json)", uploadOutput)
+	}
+}
+
+// uploadJSON reports whether upload results are written as JSON. Progress
+// and per-file lines are then suppressed so stdout holds only the summary.
+func uploadJSON() bool {
+	return uploadOutput == "json"
+}
+
+// printUploadSummaryJSON writes the summary of results to stdout as JSON
+func printUploadSummaryJSON(results []upload.UploadResult) {
+	if results == nil {
+		results = []upload.UploadResult{}
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(upload.NewUploadSummary(results)); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to encode upload summary: %v\n", err)
+	}
+}
+
+// openUploadJournal returns the progress journal for uploadType. Every run
+// records its completed files, so an interrupted run can be resumed even if
+// it was not started with --resume. With --resume the journal of the earlier
+// run is loaded and its files are skipped; otherwise a new one is started,
+// which skips nothing.
+func openUploadJournal(uploadType string) (*upload.Journal, error) {
+	if !uploadResume {
+		return upload.NewJournal(uploadType)
+	}
+	journal, err := upload.LoadJournal(uploadType)
+	if err != nil {
+		return nil, err
+	}
+	if n := journal.Len(); n > 0 {
+		infof("Resuming: %d %s file(s) uploaded by an earlier run will be skipped if unchanged\n", n, uploadType)
+	}
+	return journal, nil
+}
+
+// resumedResult is the result recorded for a file skipped by --resume
+func resumedResult(file string) upload.UploadResult {
+	return upload.UploadResult{
+		FilePath: file,
+		FileName: filepath.Base(file),
+		Status:   upload.StatusSuccess,
+		Message:  "Already uploaded by an earlier run (--resume)",
+	}
+}
+
+// markUploadCompleted records a successfully uploaded file in the journal
+func markUploadCompleted(journal *upload.Journal, file string) {
+	if journal == nil {
+		return
+	}
+	if err := journal.MarkCompleted(file); err != nil {
+		logger.Warn("Failed to record %s in upload journal: %v", file, err)
+	}
+}
+
+// closeUploadJournal deletes the journal once every file of the batch has
+// been uploaded. After a failure or interruption it is kept for the next
+// --resume run.
+func closeUploadJournal(ctx context.Context, journal *upload.Journal, results []upload.UploadResult) {
+	if journal == nil || ctx.Err() != nil || upload.NewUploadSummary(results).Failed > 0 {
+		return
+	}
+	if err := journal.Remove(); err != nil {
+		logger.Warn("Failed to remove upload journal: %v", err)
+	}
+}
+
+// cancelOnSignal calls cancel on SIGINT or SIGTERM. The upload loops then
+// stop before the next file, so the partial summary is still printed and the
+// journal kept. The returned func stops listening for signals.
+func cancelOnSignal(cancel context.CancelFunc) func() {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-sigChan:
+			warnln("\n\n" + i18n.T("upload.cancelled"))
+			cancel()
+		case <-done:
+		}
+	}()
+	return func() {
+		signal.Stop(sigChan)
+		close(done)
+	}
+}
+
+// errUploadInterrupted is returned after an upload was cancelled by a signal,
+// so that the process exits non-zero even though some files were uploaded
+func errUploadInterrupted() error {
+	return clierrors.NewCLIError(context.Canceled, "upload interrupted", clierrors.ExitInterrupted)
+}
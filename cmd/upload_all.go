@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/momorph/cli/internal/auth"
+	"github.com/momorph/cli/internal/graphql"
+	"github.com/momorph/cli/internal/i18n"
+	"github.com/momorph/cli/internal/logger"
+	"github.com/momorph/cli/internal/upload"
+	"github.com/spf13/cobra"
+)
+
+var (
+	allUploadDir      string
+	allUploadDryRun   bool
+	allUploadContinue bool
+)
+
+var uploadAllCmd = &cobra.Command{
+	Use:   "all",
+	Short: "Upload both specs and test cases to MoMorph server",
+	Long: `Upload every spec and test case CSV file found under a .momorph directory.
+
+Files are discovered recursively, as with --dir -r on the individual commands:
+  {dir}/specs/{file_key}/{frame_id}-{frame_name}.csv
+  {dir}/testcases/{file_key}/{frame_id}-{frame_name}.csv
+
+Specs are uploaded first, then test cases, with default options for each.
+`,
+	Example: `  # Upload everything in the project's .momorph directory
+  momorph upload all
+
+  # Preview what would be uploaded
+  momorph upload all --dir .momorph --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runUploadAll,
+}
+
+func init() {
+	uploadAllCmd.Flags().StringVarP(&allUploadDir, "dir", "d", ".momorph", "The .momorph directory to search")
+	uploadAllCmd.Flags().BoolVar(&allUploadDryRun, "dry-run", false, "Show what would be uploaded without actually uploading")
+	uploadAllCmd.Flags().BoolVar(&allUploadContinue, "continue-on-error", false, "Continue uploading remaining files if one fails")
+	uploadCmd.AddCommand(uploadAllCmd)
+}
+
+func runUploadAll(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Signal handling for graceful cancellation
+	stopSignals := cancelOnSignal(cancel)
+	defer stopSignals()
+	defer startHTTPTimings()()
+
+	if err := validateUploadOutput(setFlags(cmd, "dry-run")...); err != nil {
+		return err
+	}
+
+	// Resolve and validate both file types
+	specFiles, err := upload.ResolveFiles(nil, allUploadDir, true, "specs")
+	if err != nil {
+		return fmt.Errorf("failed to resolve spec files: %w", err)
+	}
+	testcaseFiles, err := upload.ResolveFiles(nil, allUploadDir, true, "testcases")
+	if err != nil {
+		return fmt.Errorf("failed to resolve test case files: %w", err)
+	}
+
+	if len(specFiles) == 0 && len(testcaseFiles) == 0 {
+		if uploadJSON() {
+			printUploadSummaryJSON(nil)
+			return nil
+		}
+		fmt.Println(i18n.T("upload.no_files"))
+		fmt.Println("\n" + i18n.T("upload.path_hint"))
+		fmt.Println("  .momorph/{testcases|specs}/{file_key}/{frame_id}-{frame_name}.csv")
+		return nil
+	}
+
+	validSpecs, skippedSpecs := upload.ValidateFiles(specFiles, "specs")
+	validTestcases, skippedTestcases := upload.ValidateFiles(testcaseFiles, "testcases")
+
+	for _, s := range append(append([]upload.UploadResult{}, skippedSpecs...), skippedTestcases...) {
+		infof("  [SKIPPED] %s\n", s.FileName)
+		infof("    Reason: %s\n", s.Message)
+	}
+
+	if len(validSpecs) == 0 && len(validTestcases) == 0 {
+		if uploadJSON() {
+			printUploadSummaryJSON(append(skippedSpecs, skippedTestcases...))
+			return nil
+		}
+		fmt.Println("\n" + i18n.T("upload.no_valid_files"))
+		return nil
+	}
+
+	// Dry run mode
+	if allUploadDryRun {
+		fmt.Printf("\n[DRY RUN] Would upload %d spec file(s) and %d test case file(s):\n", len(validSpecs), len(validTestcases))
+		for _, group := range []struct {
+			label string
+			files []string
+		}{{"specs", validSpecs}, {"testcases", validTestcases}} {
+			for _, f := range group.files {
+				parsed, _ := upload.ParseFilePath(f)
+				fmt.Printf("  - [%s] %s (file %s, frame %s)\n", group.label, filepath.Base(f), parsed.FileKey, parsed.FrameID)
+			}
+		}
+		return nil
+	}
+
+	// Check authentication
+	if !auth.IsAuthenticated() {
+		fmt.Println(i18n.T("auth.not_authenticated"))
+		fmt.Println("\n" + i18n.T("auth.login_before_upload"))
+		return errNotAuthenticated()
+	}
+
+	client, err := graphql.NewClient()
+	if err != nil {
+		logger.Error("Failed to create GraphQL client", err)
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+	client.SetRateLimit(uploadRateLimit)
+
+	// Fail before the first file if the session has been revoked
+	if err := checkSessionBeforeUpload(ctx); err != nil {
+		return err
+	}
+
+	specJournal, err := openUploadJournal("specs")
+	if err != nil {
+		return err
+	}
+	testcaseJournal, err := openUploadJournal("testcases")
+	if err != nil {
+		return err
+	}
+
+	var specResults, testcaseResults []upload.UploadResult
+
+	if len(validSpecs) > 0 {
+		actor, err := getActorEmail()
+		if err != nil {
+			logger.Warn("Failed to get user email: %v", err)
+			warnln("⚠ Could not get user email for revision tracking")
+		}
+
+		infof("\n%s\n", i18n.T("upload.uploading_specs", len(validSpecs)))
+		specResults = uploadSpecFiles(ctx, client, validSpecs, specUploadOptions{
+			actor:           actor,
+			continueOnError: allUploadContinue,
+			journal:         specJournal,
+		})
+		closeUploadJournal(ctx, specJournal, specResults)
+	}
+
+	// Without --continue-on-error, a failed spec file stops the whole run
+	specsFailed := upload.NewUploadSummary(specResults).Failed > 0
+	if len(validTestcases) > 0 && ctx.Err() == nil && (allUploadContinue || !specsFailed) {
+		infof("\n%s\n", i18n.T("upload.uploading_testcases", len(validTestcases)))
+		testcaseResults = uploadTestcaseFiles(ctx, client, validTestcases, testcaseUploadOptions{
+			continueOnError: allUploadContinue,
+			mode:            upload.TestcaseModeReplace,
+			journal:         testcaseJournal,
+		})
+		closeUploadJournal(ctx, testcaseJournal, testcaseResults)
+	}
+
+	specResults = append(skippedSpecs, specResults...)
+	testcaseResults = append(skippedTestcases, testcaseResults...)
+
+	// Per-type breakdown, then the merged summary
+	specSummary := upload.NewUploadSummary(specResults)
+	testcaseSummary := upload.NewUploadSummary(testcaseResults)
+	infoln()
+	infoln(i18n.T("upload.all_specs", specSummary.Success, specSummary.Failed, specSummary.Skipped))
+	infoln(i18n.T("upload.all_testcases", testcaseSummary.Success, testcaseSummary.Failed, testcaseSummary.Skipped))
+
+	displayUploadSummary(append(specResults, testcaseResults...))
+	if ctx.Err() != nil {
+		return errUploadInterrupted()
+	}
+
+	return nil
+}
@@ -5,14 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/signal"
 	"path/filepath"
-	"syscall"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/momorph/cli/internal/auth"
 	"github.com/momorph/cli/internal/graphql"
+	"github.com/momorph/cli/internal/i18n"
 	"github.com/momorph/cli/internal/logger"
 	"github.com/momorph/cli/internal/upload"
+	"github.com/momorph/cli/internal/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -21,8 +24,40 @@ var (
 	specUploadRecursive bool
 	specUploadDryRun    bool
 	specUploadContinue  bool
+	specValidateOnly    bool
+	specUploadStrict    bool
+	specUploadDiff      bool
+	specDiffSummaryOnly bool
+	specForceAll        bool
+	specOnlyChanged     bool
+	specForceRevisions  bool
+	specCheckFrames     bool
+	specValidateServer  bool
+	specUploadBatch     bool
+	// specFromStdin reads one CSV from stdin; with no path to parse, the
+	// target comes from specStdinFileKey and specStdinFrame
+	specFromStdin    bool
+	specStdinFileKey string
+	specStdinFrame   string
 )
 
+// stdinFileName labels the CSV read by --from-stdin in output and results
+const stdinFileName = "<stdin>"
+
+// specUploadOptions controls how individual spec files are uploaded
+type specUploadOptions struct {
+	actor           string // email used for revision tracking, empty to skip revisions
+	continueOnError bool   // keep going after a file fails
+	strict          bool   // fail the whole file if any spec is invalid
+	forceAll        bool   // upsert unchanged specs too instead of skipping them
+	forceRevisions  bool   // with forceAll, record a revision for every upserted spec
+	validateOnly    bool   // run every server-side check but stop before the upsert
+	batch           bool   // upsert all files of a frame together after checking every file
+	// journal records completed files; with --resume it holds the files of
+	// the earlier run, which are skipped
+	journal *upload.Journal
+}
+
 // CSV columns are mapped to spec fields:
 //
 //	No -> no, itemName -> design_item_name, nameJP -> name, nameTrans -> nameTrans,
@@ -32,7 +67,9 @@ var (
 //	defaultValue -> defaultValue, validationNote -> validationNote,
 //	userAction -> action, transitionNote -> navigationNote,
 //	databaseTable -> tableName, databaseColumn -> columnName,
-//	databaseNote -> databaseNote, description -> description
+//	databaseNote -> databaseNote, description -> description,
+//	status -> status (optional: none, draft or completed; inferred when blank),
+//	reviewed -> is_reviewed (optional: true/false, yes/no or 1/0; kept when blank)
 var uploadSpecsCmd = &cobra.Command{
 	Use:   "specs [files...]",
 	Short: "Upload specs to MoMorph server",
@@ -40,6 +77,14 @@ var uploadSpecsCmd = &cobra.Command{
 
 Files must follow the path pattern:
   .momorph/specs/{file_key}/{frame_id}-{frame_name}.csv
+
+An optional "status" column (none, draft or completed) sets each spec's
+status explicitly. When it is blank, the status already on the server is
+kept, and new specs are marked completed if they pass completed validation
+or draft otherwise.
+
+An optional "reviewed" column (true/false, yes/no or 1/0) marks specs as
+reviewed. When it is blank, the reviewed flag on the server is kept.
 `,
 	Example: `  # Upload a single file
   momorph upload specs .momorph/specs/xxx/yyy.csv
@@ -54,8 +99,33 @@ Files must follow the path pattern:
   momorph upload specs ".momorph/specs/**/*.csv"
 
   # Dry run (show what would be uploaded)
-  momorph upload specs --dry-run .momorph/specs/**/*.csv`,
-	RunE: runUploadSpecs,
+  momorph upload specs --dry-run .momorph/specs/**/*.csv
+
+  # Preview field-level changes against the server without uploading
+  momorph upload specs --diff .momorph/specs/**/*.csv
+
+  # Re-send every valid spec, even those that match the server
+  momorph upload specs --force-all .momorph/specs/**/*.csv
+
+  # Check that no target frame is still in 'design' status before uploading
+  momorph upload specs --check-frames .momorph/specs/**/*.csv
+
+  # Skip files already uploaded if an earlier run was interrupted
+  momorph upload specs --resume --dir .momorph/specs/ -r
+
+  # Validate CSVs locally without authenticating (e.g. in a pre-commit hook)
+  momorph upload specs --validate-only .momorph/specs/**/*.csv
+
+  # Upsert files that target the same frame together (fewer round trips)
+  momorph upload specs --batch --dir .momorph/specs/ -r
+
+  # Check frames, existing items and linked frames on the server, upload nothing
+  momorph upload specs --validate-server .momorph/specs/**/*.csv
+
+  # Upload a CSV piped from another program, without writing it to disk
+  generate-specs | momorph upload specs --from-stdin --file-key i09vM3jClQiu8cwXsMo6uy --frame 9276:19907`,
+	ValidArgsFunction: completeUploadFiles("specs"),
+	RunE:              runUploadSpecs,
 }
 
 func init() {
@@ -63,6 +133,25 @@ func init() {
 	uploadSpecsCmd.Flags().BoolVarP(&specUploadRecursive, "recursive", "r", false, "Search directories recursively")
 	uploadSpecsCmd.Flags().BoolVar(&specUploadDryRun, "dry-run", false, "Show what would be uploaded without actually uploading")
 	uploadSpecsCmd.Flags().BoolVar(&specUploadContinue, "continue-on-error", false, "Continue uploading remaining files if one fails")
+	uploadSpecsCmd.Flags().BoolVar(&specUploadStrict, "strict", false, "Fail a file without uploading anything if any spec in it is invalid")
+	uploadSpecsCmd.Flags().BoolVar(&specUploadDiff, "diff", false, "Show a field-level diff against the server without uploading")
+	uploadSpecsCmd.Flags().BoolVar(&specDiffSummaryOnly, "diff-summary", false, "Like --diff, but only print per-file counts of new/changed/unchanged/invalid specs")
+	uploadSpecsCmd.Flags().BoolVar(&specValidateOnly, "validate-only", false, "Validate CSV files locally without authenticating or uploading")
+	uploadSpecsCmd.Flags().BoolVar(&specForceAll, "force-all", false, "Upsert every valid spec, including those unchanged on the server")
+	uploadSpecsCmd.Flags().BoolVar(&specOnlyChanged, "only-changed", false, "Only upsert new or changed specs (default)")
+	uploadSpecsCmd.Flags().BoolVar(&specForceRevisions, "force-revisions", false, "With --force-all, record a revision for every upserted spec, not only changed ones")
+	uploadSpecsCmd.Flags().BoolVar(&specCheckFrames, "check-frames", false, "Check every target frame first and upload nothing if any is missing or in 'design' status")
+	uploadSpecsCmd.Flags().BoolVar(&specValidateServer, "validate-server", false, "Run every server-side check (frame status, existing items, linked frames) and report what would change, without uploading")
+	uploadSpecsCmd.Flags().BoolVar(&specUploadBatch, "batch", false, "Check every file first, then upsert all specs for the same frame in one request")
+	uploadSpecsCmd.Flags().BoolVar(&specFromStdin, "from-stdin", false, "Read a single specs CSV from stdin (requires --file-key and --frame)")
+	uploadSpecsCmd.Flags().StringVar(&specStdinFileKey, "file-key", "", "With --from-stdin, the file key the specs belong to")
+	uploadSpecsCmd.Flags().StringVar(&specStdinFrame, "frame", "", "With --from-stdin, the frame ID the specs belong to (e.g. 9276:19907)")
+	uploadSpecsCmd.MarkFlagsRequiredTogether("from-stdin", "file-key", "frame")
+	for _, name := range []string{"dir", "recursive", "diff", "diff-summary", "check-frames", "batch"} {
+		uploadSpecsCmd.MarkFlagsMutuallyExclusive("from-stdin", name)
+	}
+	uploadSpecsCmd.MarkFlagsMutuallyExclusive("force-all", "only-changed")
+	uploadSpecsCmd.MarkFlagsMutuallyExclusive("validate-server", "validate-only", "dry-run", "diff", "diff-summary")
 	uploadCmd.AddCommand(uploadSpecsCmd)
 }
 
@@ -72,27 +161,25 @@ func runUploadSpecs(cmd *cobra.Command, args []string) error {
 	defer cancel()
 
 	// Signal handling for graceful cancellation
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	go func() {
-		<-sigChan
-		fmt.Println("\n\n✗ Upload cancelled")
-		cancel()
-		os.Exit(0)
-	}()
+	stopSignals := cancelOnSignal(cancel)
+	defer stopSignals()
+	defer startHTTPTimings()()
 
-	// Check authentication
-	if !auth.IsAuthenticated() {
-		fmt.Println("✗ Not authenticated")
-		fmt.Println("\nRun 'momorph login' to authenticate before uploading")
-		return nil
+	if specForceRevisions && !specForceAll {
+		return fmt.Errorf("--force-revisions requires --force-all")
+	}
+	if err := validateUploadOutput(setFlags(cmd, "dry-run", "diff", "diff-summary", "validate-only")...); err != nil {
+		return err
 	}
 
-	// Get actor email for revision tracking
-	actor, err := getActorEmail()
-	if err != nil {
-		logger.Warn("Failed to get user email: %v", err)
-		fmt.Println("⚠ Could not get user email for revision tracking")
+	if specFromStdin {
+		if len(args) > 0 {
+			return fmt.Errorf("--from-stdin cannot be combined with file arguments")
+		}
+		if uploadResume {
+			return fmt.Errorf("--resume cannot be used with --from-stdin")
+		}
+		return runUploadSpecsFromStdin(ctx)
 	}
 
 	// Resolve files
@@ -102,8 +189,12 @@ func runUploadSpecs(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(files) == 0 {
-		fmt.Println("No CSV files found to upload")
-		fmt.Println("\nMake sure files are in the correct path format:")
+		if uploadJSON() {
+			printUploadSummaryJSON(nil)
+			return nil
+		}
+		fmt.Println(i18n.T("upload.no_files"))
+		fmt.Println("\n" + i18n.T("upload.path_hint"))
 		fmt.Println("  .momorph/specs/{file_key}/{frame_id}-{frame_name}.csv")
 		return nil
 	}
@@ -113,15 +204,38 @@ func runUploadSpecs(cmd *cobra.Command, args []string) error {
 
 	// Print skipped files
 	for _, s := range skipped {
-		fmt.Printf("  [SKIPPED] %s\n", s.FileName)
-		fmt.Printf("    Reason: %s\n", s.Message)
+		infof("  [SKIPPED] %s\n", s.FileName)
+		infof("    Reason: %s\n", s.Message)
 	}
 
 	if len(validFiles) == 0 {
-		fmt.Println("\nNo valid files to upload")
+		if uploadJSON() {
+			printUploadSummaryJSON(skipped)
+			return nil
+		}
+		fmt.Println("\n" + i18n.T("upload.no_valid_files"))
 		return nil
 	}
 
+	// Validate-only mode runs fully offline
+	if specValidateOnly {
+		return runValidateSpecFiles(validFiles)
+	}
+
+	// Check authentication
+	if !auth.IsAuthenticated() {
+		fmt.Println(i18n.T("auth.not_authenticated"))
+		fmt.Println("\n" + i18n.T("auth.login_before_upload"))
+		return errNotAuthenticated()
+	}
+
+	// Get actor email for revision tracking
+	actor, err := getActorEmail()
+	if err != nil {
+		logger.Warn("Failed to get user email: %v", err)
+		warnln("⚠ Could not get user email for revision tracking")
+	}
+
 	// Dry run mode
 	if specUploadDryRun {
 		fmt.Printf("\n[DRY RUN] Would upload %d file(s):\n", len(validFiles))
@@ -133,6 +247,12 @@ func runUploadSpecs(cmd *cobra.Command, args []string) error {
 			fmt.Printf("    Frame ID: %s\n", parsed.FrameID)
 			fmt.Printf("    Frame Name: %s\n", parsed.FrameName)
 			fmt.Printf("    Specs count: %d\n", len(specs))
+			if _, duplicates := upload.FindDuplicateSpecs(specs); len(duplicates) > 0 {
+				fmt.Printf("    ⚠ %d duplicate row(s) will be skipped:\n", len(duplicates))
+				for _, dup := range duplicates {
+					fmt.Printf("      Row %d (itemId %s): %s\n", dup.Spec.Row, dup.Spec.NodeLinkID, strings.Join(dup.Errors, "; "))
+				}
+			}
 		}
 		return nil
 	}
@@ -143,21 +263,422 @@ func runUploadSpecs(cmd *cobra.Command, args []string) error {
 		logger.Error("Failed to create GraphQL client", err)
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
+	client.SetRateLimit(uploadRateLimit)
+
+	// Diff mode compares against the server without uploading
+	if specUploadDiff || specDiffSummaryOnly {
+		return runDiffSpecFiles(ctx, client, validFiles, specDiffSummaryOnly)
+	}
+
+	// Preflight: report rejected frames before anything is uploaded
+	if specCheckFrames {
+		infoln("\n🔍 Checking target frames...")
+		blocked := checkTargetFrames(ctx, client, validFiles)
+		if len(blocked) > 0 {
+			fmt.Printf("\n✗ %d frame(s) cannot receive uploads:\n", len(blocked))
+			for _, line := range blocked {
+				fmt.Printf("  - %s\n", line)
+			}
+			return fmt.Errorf("frame check failed, nothing was uploaded")
+		}
+		infoln("✓ All target frames accept uploads")
+	}
+
+	// Fail before the first file if the session has been revoked
+	if err := checkSessionBeforeUpload(ctx); err != nil {
+		return err
+	}
+
+	// Nothing is uploaded when validating, so there is no progress to record
+	var journal *upload.Journal
+	if !specValidateServer {
+		journal, err = openUploadJournal("specs")
+		if err != nil {
+			return err
+		}
+	}
 
 	// Upload files
-	fmt.Printf("\nUploading %d spec file(s)...\n", len(validFiles))
-	results := uploadSpecFiles(ctx, client, validFiles, actor, specUploadContinue)
+	if specValidateServer {
+		infof("\n[VALIDATE] Checking %d spec file(s) against the server, nothing will be uploaded\n", len(validFiles))
+	} else {
+		infof("\n%s\n", i18n.T("upload.uploading_specs", len(validFiles)))
+	}
+	opts := specUploadOptions{
+		actor:           actor,
+		continueOnError: specUploadContinue,
+		strict:          specUploadStrict,
+		forceAll:        specForceAll,
+		forceRevisions:  specForceRevisions,
+		validateOnly:    specValidateServer,
+		batch:           specUploadBatch,
+		journal:         journal,
+	}
+	results := uploadSpecFiles(ctx, client, validFiles, opts)
+	closeUploadJournal(ctx, journal, results)
 
 	// Combine with skipped files
 	allResults := append(skipped, results...)
 
 	// Display summary
 	displayUploadSummary(allResults)
+	if ctx.Err() != nil {
+		return errUploadInterrupted()
+	}
+
+	// Like --validate-only, a validation run fails when a file would fail
+	if specValidateServer {
+		infoln("\n[VALIDATE] Nothing was uploaded")
+		if summary := upload.NewUploadSummary(allResults); summary.Failed > 0 {
+			return fmt.Errorf("validation failed: %d file(s) would fail to upload", summary.Failed)
+		}
+	}
+
+	return nil
+}
+
+// runUploadSpecsFromStdin uploads the specs CSV piped on stdin to the frame
+// given by --file-key and --frame. It supports the same modes as a single
+// file, except those that need a path (--diff, --check-frames, --resume).
+func runUploadSpecsFromStdin(ctx context.Context) error {
+	parsed := &upload.ParsedFilePath{
+		Type:    "specs",
+		FileKey: strings.TrimSpace(specStdinFileKey),
+		FrameID: strings.TrimSpace(specStdinFrame),
+	}
+	if parsed.FileKey == "" || parsed.FrameID == "" {
+		return fmt.Errorf("--file-key and --frame must not be empty")
+	}
+
+	specs, err := upload.ParseSpecsReader(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("failed to parse CSV from stdin: %w", err)
+	}
+	logger.Debug("Parsed %d specs from stdin", len(specs))
+
+	// Validate-only mode runs fully offline
+	if specValidateOnly {
+		valid, invalid := upload.ValidateSpecs(specs)
+		if len(invalid) > 0 {
+			fmt.Printf("%s .... %d of %d specs invalid\n", stdinFileName, len(invalid), len(specs))
+			for _, detail := range describeInvalidSpecs(invalid) {
+				fmt.Printf("    - %s\n", detail)
+			}
+			return fmt.Errorf("validation failed: %d invalid row(s)", len(invalid))
+		}
+		fmt.Printf("%s .... ok (%d specs)\n", stdinFileName, len(valid))
+		return nil
+	}
+
+	// Check authentication
+	if !auth.IsAuthenticated() {
+		fmt.Println(i18n.T("auth.not_authenticated"))
+		fmt.Println("\n" + i18n.T("auth.login_before_upload"))
+		return errNotAuthenticated()
+	}
+
+	actor, err := getActorEmail()
+	if err != nil {
+		logger.Warn("Failed to get user email: %v", err)
+		warnln("⚠ Could not get user email for revision tracking")
+	}
 
+	if specUploadDryRun {
+		fmt.Printf("\n[DRY RUN] Would upload %s:\n", stdinFileName)
+		fmt.Printf("    File Key: %s\n", parsed.FileKey)
+		fmt.Printf("    Frame ID: %s\n", parsed.FrameID)
+		fmt.Printf("    Specs count: %d\n", len(specs))
+		return nil
+	}
+
+	client, err := graphql.NewClient()
+	if err != nil {
+		logger.Error("Failed to create GraphQL client", err)
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+	client.SetRateLimit(uploadRateLimit)
+
+	if err := checkSessionBeforeUpload(ctx); err != nil {
+		return err
+	}
+
+	opts := specUploadOptions{
+		actor:          actor,
+		strict:         specUploadStrict,
+		forceAll:       specForceAll,
+		forceRevisions: specForceRevisions,
+		validateOnly:   specValidateServer,
+	}
+	fileLine := fmt.Sprintf("  %s ", stdinFileName)
+	infof("%s", fileLine)
+
+	var result upload.UploadResult
+	if prepared, done := prepareSpecs(ctx, client, stdinFileName, parsed, specs, opts); done != nil {
+		result = *done
+	} else {
+		result = upsertPreparedSpecFile(ctx, client, prepared, opts)
+	}
+	reportSpecResult(fileLine, result, opts)
+
+	results := []upload.UploadResult{result}
+	displayUploadSummary(results)
+	if ctx.Err() != nil {
+		return errUploadInterrupted()
+	}
+	if specValidateServer {
+		infoln("\n[VALIDATE] Nothing was uploaded")
+		if result.Status == upload.StatusFailed {
+			return fmt.Errorf("validation failed: %s would fail to upload", stdinFileName)
+		}
+	}
 	return nil
 }
 
-func uploadSpecFiles(ctx context.Context, client *graphql.Client, files []string, actor string, continueOnError bool) []upload.UploadResult {
+// runValidateSpecFiles validates every row of the given files without any
+// network access and returns an error if any row is invalid
+func runValidateSpecFiles(files []string) error {
+	fmt.Printf("\nValidating %d spec file(s)...\n", len(files))
+
+	invalidFiles := 0
+	invalidRows := 0
+	for i, file := range files {
+		fmt.Printf("  [%d/%d] %s ", i+1, len(files), filepath.Base(file))
+
+		specs, err := upload.ParseSpecsCSV(file)
+		if err != nil {
+			fmt.Println(".... failed")
+			fmt.Printf("    Error: %v\n", err)
+			invalidFiles++
+			continue
+		}
+
+		valid, invalid := upload.ValidateSpecs(specs)
+		if len(invalid) == 0 {
+			fmt.Printf(".... ok (%d specs)\n", len(valid))
+			continue
+		}
+
+		fmt.Printf(".... %d of %d specs invalid\n", len(invalid), len(specs))
+		for _, detail := range describeInvalidSpecs(invalid) {
+			fmt.Printf("    - %s\n", detail)
+		}
+		invalidFiles++
+		invalidRows += len(invalid)
+	}
+
+	if invalidFiles > 0 {
+		return fmt.Errorf("validation failed: %d invalid row(s) in %d file(s)", invalidRows, invalidFiles)
+	}
+
+	fmt.Printf("\n✓ All %d file(s) are valid\n", len(files))
+	return nil
+}
+
+// Kinds of difference reported by --diff
+const (
+	specDiffNew       = "new"
+	specDiffChanged   = "changed"
+	specDiffUnchanged = "unchanged"
+	specDiffDeleted   = "deleted"
+	specDiffInvalid   = "invalid"
+)
+
+// specRowDiff describes how one CSV row compares to the server
+type specRowDiff struct {
+	spec   upload.Spec
+	kind   string
+	fields []upload.SpecFieldDiff // set for changed rows
+	errors []string               // set for invalid rows
+}
+
+// runDiffSpecFiles prints how each file's specs differ from the design items
+// stored on the server. Nothing is uploaded. With summaryOnly set, only the
+// per-file counts are printed.
+func runDiffSpecFiles(ctx context.Context, client *graphql.Client, files []string, summaryOnly bool) error {
+	fmt.Printf("\nComparing %d spec file(s) with server...\n", len(files))
+
+	total := make(map[string]int)
+	for i, file := range files {
+		select {
+		case <-ctx.Done():
+			return errUploadInterrupted()
+		default:
+		}
+
+		fmt.Printf("\n  [%d/%d] %s\n", i+1, len(files), filepath.Base(file))
+
+		diffs, err := diffSpecFile(ctx, client, file)
+		if ctx.Err() != nil {
+			return errUploadInterrupted()
+		}
+		if err != nil {
+			fmt.Printf("    Error: %v\n", err)
+			continue
+		}
+		if !summaryOnly {
+			printSpecFileDiff(diffs)
+		}
+
+		counts := countSpecDiffs(diffs)
+		for kind, n := range counts {
+			total[kind] += n
+		}
+		fmt.Printf("    %s\n", formatSpecDiffCounts(counts))
+	}
+
+	if len(files) > 1 {
+		fmt.Println("\n─────────────────────────────────────────")
+		fmt.Printf("Total: %s\n", formatSpecDiffCounts(total))
+	}
+
+	return nil
+}
+
+// countSpecDiffs counts rows by kind of difference
+func countSpecDiffs(diffs []specRowDiff) map[string]int {
+	counts := make(map[string]int)
+	for _, d := range diffs {
+		counts[d.kind]++
+	}
+	return counts
+}
+
+// formatSpecDiffCounts renders counts as e.g. "2 new, 1 changed, 10 unchanged, 0 invalid";
+// deleted rows are only mentioned when present
+func formatSpecDiffCounts(counts map[string]int) string {
+	summary := fmt.Sprintf("%d new, %d changed, %d unchanged, %d invalid",
+		counts[specDiffNew], counts[specDiffChanged], counts[specDiffUnchanged], counts[specDiffInvalid])
+	if counts[specDiffDeleted] > 0 {
+		summary += fmt.Sprintf(", %d deleted in Figma", counts[specDiffDeleted])
+	}
+	return summary
+}
+
+// diffSpecFile compares every spec in a CSV file with its server counterpart
+func diffSpecFile(ctx context.Context, client *graphql.Client, filePath string) ([]specRowDiff, error) {
+	parsed, err := upload.ParseFilePath(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	specs, err := upload.ParseSpecsCSV(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+
+	specs, duplicateSpecs := upload.FindDuplicateSpecs(specs)
+
+	if _, err := client.GetFrame(ctx, parsed.FileKey, parsed.FrameID); err != nil {
+		return nil, fmt.Errorf("frame not found: %w", err)
+	}
+
+	var nodeLinkIds []string
+	for _, spec := range specs {
+		if spec.NodeLinkID != "" {
+			nodeLinkIds = append(nodeLinkIds, spec.NodeLinkID)
+		}
+	}
+
+	existingMap := make(map[string]graphql.DesignItem)
+	if len(nodeLinkIds) > 0 {
+		existingItems, err := client.ListDesignItemsByNodeLinkIds(ctx, parsed.FileKey, parsed.FrameID, nodeLinkIds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get existing design items: %w", err)
+		}
+		for _, item := range existingItems {
+			existingMap[item.NodeLinkID] = item
+		}
+	}
+
+	var diffs []specRowDiff
+	for _, dup := range duplicateSpecs {
+		diffs = append(diffs, specRowDiff{spec: dup.Spec, kind: specDiffInvalid, errors: dup.Errors})
+	}
+
+	for _, spec := range specs {
+		existingItem, exists := existingMap[spec.NodeLinkID]
+
+		if exists && existingItem.Status == upload.DesignItemStatusDeleted {
+			diffs = append(diffs, specRowDiff{spec: spec, kind: specDiffDeleted})
+			continue
+		}
+
+		existingStatus := ""
+		if exists {
+			existingStatus = existingItem.Status
+		}
+		if _, validationErrors := upload.DetermineSpecStatus(&spec, existingStatus); len(validationErrors) > 0 {
+			diffs = append(diffs, specRowDiff{spec: spec, kind: specDiffInvalid, errors: validationErrors})
+			continue
+		}
+
+		if !exists {
+			diffs = append(diffs, specRowDiff{spec: spec, kind: specDiffNew})
+			continue
+		}
+
+		existingSpec := convertDesignItemToSpec(existingItem)
+		fields := upload.DiffSpecs(upload.MapSpecForComparison(&spec), upload.MapSpecForComparison(&existingSpec))
+		if len(fields) == 0 {
+			diffs = append(diffs, specRowDiff{spec: spec, kind: specDiffUnchanged})
+			continue
+		}
+		diffs = append(diffs, specRowDiff{spec: spec, kind: specDiffChanged, fields: fields})
+	}
+
+	sort.SliceStable(diffs, func(i, j int) bool { return diffs[i].spec.Row < diffs[j].spec.Row })
+	return diffs, nil
+}
+
+// printSpecFileDiff prints the rows of a file that would change on upload
+func printSpecFileDiff(diffs []specRowDiff) {
+	printed := 0
+	for _, d := range diffs {
+		label := fmt.Sprintf("Row %d (itemId %s)", d.spec.Row, d.spec.NodeLinkID)
+
+		switch d.kind {
+		case specDiffNew:
+			fmt.Printf("    + %s: new\n", label)
+		case specDiffChanged:
+			fmt.Printf("    ~ %s\n", label)
+			for _, f := range d.fields {
+				fmt.Printf("        %s: %s -> %s\n", f.Field, formatDiffValue(f.Previous), formatDiffValue(f.Current))
+			}
+		case specDiffDeleted:
+			fmt.Printf("    - %s: deleted in Figma\n", label)
+		case specDiffInvalid:
+			fmt.Printf("    ! %s: %s\n", label, strings.Join(d.errors, "; "))
+		default:
+			continue
+		}
+		printed++
+	}
+
+	if printed == 0 {
+		fmt.Println("    No changes")
+	}
+}
+
+// formatDiffValue renders a comparison value for diff output
+func formatDiffValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "(empty)"
+	case string:
+		if v == "" {
+			return "(empty)"
+		}
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func uploadSpecFiles(ctx context.Context, client *graphql.Client, files []string, opts specUploadOptions) []upload.UploadResult {
+	if opts.batch && !opts.validateOnly {
+		return uploadSpecFilesBatched(ctx, client, files, opts)
+	}
+
 	var results []upload.UploadResult
 
 	for i, file := range files {
@@ -168,37 +689,419 @@ func uploadSpecFiles(ctx context.Context, client *graphql.Client, files []string
 		default:
 		}
 
-		fileName := filepath.Base(file)
-		fmt.Printf("  [%d/%d] %s ", i+1, len(files), fileName)
+		// In quiet mode the file line is only printed if the upload fails
+		fileLine := fmt.Sprintf("  [%d/%d] %s ", i+1, len(files), filepath.Base(file))
+		infof("%s", fileLine)
+
+		if opts.journal != nil && opts.journal.IsCompleted(file) {
+			infoln(".... already uploaded")
+			results = append(results, resumedResult(file))
+			continue
+		}
 
-		result := uploadSingleSpecFile(ctx, client, file, actor)
+		result := uploadSingleSpecFile(ctx, client, file, opts)
 		results = append(results, result)
 
-		switch result.Status {
-		case upload.StatusSuccess:
-			fmt.Println(".... done")
-		case upload.StatusFailed:
+		if !reportSpecResult(fileLine, result, opts) {
+			return results
+		}
+	}
+
+	return results
+}
+
+// reportSpecResult prints the outcome of a file after its file line and
+// records successful files in the journal. It returns false when the run
+// should stop because the file failed.
+func reportSpecResult(fileLine string, result upload.UploadResult, opts specUploadOptions) bool {
+	switch result.Status {
+	case upload.StatusSuccess:
+		markUploadCompleted(opts.journal, result.FilePath)
+		if opts.validateOnly {
+			infoln(".... ok")
+			infof("    %s\n", result.Message)
+		} else {
+			infoln(".... done")
+		}
+		if !quietMode {
+			printResultDetails(result)
+		}
+	case upload.StatusFailed:
+		if !uploadJSON() {
+			if quietMode {
+				fmt.Print(fileLine)
+			}
 			fmt.Println(".... failed")
 			fmt.Printf("    Error: %s\n", result.Message)
-			if !continueOnError {
-				return results
+			printResultDetails(result)
+		}
+		return opts.continueOnError
+	case upload.StatusSkipped:
+		infoln(".... skipped")
+		infof("    Reason: %s\n", result.Message)
+	}
+	return true
+}
+
+// uploadSpecFilesBatched checks every file first, then upserts the specs of
+// all files targeting the same frame in one mutation, followed by one
+// revision insert. Results are still reported per file, in file order.
+func uploadSpecFilesBatched(ctx context.Context, client *graphql.Client, files []string, opts specUploadOptions) []upload.UploadResult {
+	outcomes := make([]*upload.UploadResult, len(files))
+	resumed := make([]bool, len(files))
+	slots := make(map[string]int) // position of each prepared file in files
+	groups := make(map[string][]*preparedSpecFile)
+	var frameKeys []string
+
+	// Check all files; without --continue-on-error a failure uploads nothing
+	stopped := false
+	for i, file := range files {
+		if ctx.Err() != nil {
+			stopped = true
+			break
+		}
+
+		if opts.journal != nil && opts.journal.IsCompleted(file) {
+			result := resumedResult(file)
+			outcomes[i] = &result
+			resumed[i] = true
+			continue
+		}
+
+		prepared, result := prepareSpecFile(ctx, client, file, opts)
+		if result != nil {
+			outcomes[i] = result
+			if result.Status == upload.StatusFailed && !opts.continueOnError {
+				stopped = true
+				break
 			}
-		case upload.StatusSkipped:
-			fmt.Println(".... skipped")
-			fmt.Printf("    Reason: %s\n", result.Message)
+			continue
 		}
+
+		slots[file] = i
+		if _, ok := groups[prepared.frameKey]; !ok {
+			frameKeys = append(frameKeys, prepared.frameKey)
+		}
+		groups[prepared.frameKey] = append(groups[prepared.frameKey], prepared)
+	}
+
+	if !stopped {
+		for _, key := range frameKeys {
+			if ctx.Err() != nil {
+				break
+			}
+			failed := false
+			for _, r := range upsertSpecGroup(ctx, client, opts, groups[key]) {
+				result := r
+				outcomes[slots[result.FilePath]] = &result
+				failed = failed || result.Status == upload.StatusFailed
+			}
+			if failed && !opts.continueOnError {
+				break
+			}
+		}
+	}
+
+	// Checked files that were not upserted are reported as skipped, so the
+	// summary accounts for them
+	notUploaded := "Not uploaded: an earlier file failed"
+	if ctx.Err() != nil {
+		notUploaded = "Not uploaded: interrupted"
+	}
+	for _, key := range frameKeys {
+		for _, f := range groups[key] {
+			if i := slots[f.filePath]; outcomes[i] == nil {
+				result := f.skippedResult(notUploaded)
+				outcomes[i] = &result
+			}
+		}
+	}
+
+	// Report in file order; files not checked before the run stopped have
+	// no outcome, as in an unbatched run
+	var results []upload.UploadResult
+	for i, outcome := range outcomes {
+		if outcome == nil {
+			continue
+		}
+		fileLine := fmt.Sprintf("  [%d/%d] %s ", i+1, len(files), filepath.Base(files[i]))
+		infof("%s", fileLine)
+		if resumed[i] {
+			infoln(".... already uploaded")
+		} else {
+			reportSpecResult(fileLine, *outcome, opts)
+		}
+		results = append(results, *outcome)
 	}
 
 	return results
 }
 
-func uploadSingleSpecFile(ctx context.Context, client *graphql.Client, filePath, actor string) upload.UploadResult {
+// upsertSpecGroup upserts the prepared files of one frame in a single
+// mutation and returns a result per file. Files that set the same itemId
+// cannot share an upsert and are sent one by one instead.
+func upsertSpecGroup(ctx context.Context, client *graphql.Client, opts specUploadOptions, files []*preparedSpecFile) []upload.UploadResult {
+	var results []upload.UploadResult
+
+	if len(files) == 1 || sharesNodeLinkIDs(files) {
+		for _, f := range files {
+			savedItems, err := client.UpsertDesignItemSpecs(ctx, f.items)
+			if err != nil {
+				results = append(results, f.failedResult(err))
+				continue
+			}
+			revErr := insertSpecRevisions(ctx, client, opts, savedItems, []*preparedSpecFile{f})
+			results = append(results, f.uploadedResult(len(savedItems), revErr))
+		}
+		return results
+	}
+
+	var items []map[string]interface{}
+	for _, f := range files {
+		items = append(items, f.items...)
+	}
+
+	logger.Debug("Upserting %d design items from %d files for frame %s", len(items), len(files), files[0].frameKey)
+	savedItems, err := client.UpsertDesignItemSpecs(ctx, items)
+	if err != nil {
+		for _, f := range files {
+			results = append(results, f.failedResult(err))
+		}
+		return results
+	}
+
+	revErr := insertSpecRevisions(ctx, client, opts, savedItems, files)
+
+	// Attribute saved items back to the file that sent them
+	saved := make(map[string]bool)
+	for _, item := range savedItems {
+		saved[item.NodeLinkID] = true
+	}
+	for _, f := range files {
+		count := 0
+		for _, item := range f.items {
+			if id, _ := item["node_link_id"].(string); saved[id] {
+				count++
+			}
+		}
+		results = append(results, f.uploadedResult(count, revErr))
+	}
+
+	return results
+}
+
+// sharesNodeLinkIDs reports whether any itemId appears in more than one file
+func sharesNodeLinkIDs(files []*preparedSpecFile) bool {
+	seen := make(map[string]bool)
+	for _, f := range files {
+		for _, item := range f.items {
+			id, _ := item["node_link_id"].(string)
+			if seen[id] {
+				return true
+			}
+			seen[id] = true
+		}
+	}
+	return false
+}
+
+// printResultDetails prints per-row details attached to an upload result
+func printResultDetails(result upload.UploadResult) {
+	for _, detail := range result.Details {
+		fmt.Printf("    - %s\n", detail)
+	}
+}
+
+// preparedSpecFile is a spec file that passed its checks and is ready to upsert
+type preparedSpecFile struct {
+	filePath     string
+	frameKey     string // file key and frame ID, files sharing it can be upserted together
+	items        []map[string]interface{}
+	validSpecs   []upload.ValidatedSpec
+	invalidSpecs []upload.ValidatedSpec
+	existingMap  map[string]graphql.DesignItem
+	unchanged    int
+}
+
+// uploadedResult is the result for a prepared file once count specs were
+// upserted. revErr is the error of recording their revisions, if any: the
+// specs are saved either way, but a missing audit trail is reported.
+func (f *preparedSpecFile) uploadedResult(count int, revErr error) upload.UploadResult {
+	message := fmt.Sprintf("Uploaded %d specs", count)
+	if len(f.invalidSpecs) > 0 {
+		message += fmt.Sprintf(" (%d invalid)", len(f.invalidSpecs))
+	}
+	if f.unchanged > 0 {
+		message += fmt.Sprintf(" (%d unchanged, skipped)", f.unchanged)
+	}
+	details := describeInvalidSpecs(f.invalidSpecs)
+	if revErr != nil {
+		message += " (revisions not recorded)"
+		details = append(details, fmt.Sprintf("Revisions were not recorded: %v", revErr))
+	}
+
+	return upload.UploadResult{
+		FilePath:  f.filePath,
+		FileName:  filepath.Base(f.filePath),
+		Status:    upload.StatusSuccess,
+		Message:   message,
+		Details:   details,
+		Unchanged: f.unchanged,
+	}
+}
+
+// skippedResult is the result for a prepared file that was not upserted
+func (f *preparedSpecFile) skippedResult(reason string) upload.UploadResult {
+	return upload.UploadResult{
+		FilePath: f.filePath,
+		FileName: filepath.Base(f.filePath),
+		Status:   upload.StatusSkipped,
+		Message:  reason,
+	}
+}
+
+// failedResult is the result for a prepared file whose upsert failed
+func (f *preparedSpecFile) failedResult(err error) upload.UploadResult {
+	return upload.UploadResult{
+		FilePath: f.filePath,
+		FileName: filepath.Base(f.filePath),
+		Status:   upload.StatusFailed,
+		Error:    err,
+		Message:  fmt.Sprintf("Failed to upsert specs: %v", err),
+	}
+}
+
+func uploadSingleSpecFile(ctx context.Context, client *graphql.Client, filePath string, opts specUploadOptions) upload.UploadResult {
+	prepared, result := prepareSpecFile(ctx, client, filePath, opts)
+	if result != nil {
+		return *result
+	}
+	return upsertPreparedSpecFile(ctx, client, prepared, opts)
+}
+
+// upsertPreparedSpecFile upserts one prepared file and records its revisions
+func upsertPreparedSpecFile(ctx context.Context, client *graphql.Client, prepared *preparedSpecFile, opts specUploadOptions) upload.UploadResult {
+	// Upsert design items
+	savedItems, err := client.UpsertDesignItemSpecs(ctx, prepared.items)
+	if err != nil {
+		return prepared.failedResult(err)
+	}
+
+	logger.Debug("Upserted %d design items", len(savedItems))
+
+	revErr := insertSpecRevisions(ctx, client, opts, savedItems, []*preparedSpecFile{prepared})
+
+	return prepared.uploadedResult(len(savedItems), revErr)
+}
+
+// revisionInsertAttempts is how many times recording revisions is tried
+// before the upload is reported without them
+const revisionInsertAttempts = 3
+
+// insertSpecRevisions records a revision for each saved item that is new or
+// changed, in one mutation. Revisions need the actor; without it nothing is
+// recorded. Revisions reference the IDs the upsert returns, so they cannot
+// be sent in the same mutation; instead an insert the server never acted on
+// (a failed connection or a 429) is retried here, the only layer that
+// retries it since the GraphQL client does not resend non-idempotent
+// mutations. Any other failure, such as a 5xx or a reset connection, may
+// come after the revisions were committed, so it is returned at once rather
+// than risk recording them twice.
+func insertSpecRevisions(ctx context.Context, client *graphql.Client, opts specUploadOptions, savedItems []graphql.DesignItem, files []*preparedSpecFile) error {
+	if opts.actor == "" {
+		return nil
+	}
+
+	user, err := client.GetMorpheusUserByEmail(ctx, opts.actor)
+	if err != nil {
+		logger.Warn("Could not get user for revision tracking: %v", err)
+		return fmt.Errorf("failed to look up user %s: %w", opts.actor, err)
+	}
+
+	// Look up the server state and CSV values of saved items by node link ID
+	existingMap := make(map[string]graphql.DesignItem)
+	validSpecs := make(map[string]upload.Spec)
+	for _, f := range files {
+		for id, item := range f.existingMap {
+			existingMap[id] = item
+		}
+		for _, vs := range f.validSpecs {
+			validSpecs[vs.NodeLinkID] = vs.Spec
+		}
+	}
+
+	// Prepare revision entries for new AND changed items
+	var revs []map[string]interface{}
+	for _, item := range savedItems {
+		existingItem, existed := existingMap[item.NodeLinkID]
+
+		shouldCreateRevision := false
+		if opts.forceAll && opts.forceRevisions {
+			// Explicitly requested revisions for everything re-sent
+			shouldCreateRevision = true
+		} else if !existed {
+			// New item - always create revision
+			shouldCreateRevision = true
+		} else if spec, ok := validSpecs[item.NodeLinkID]; ok {
+			// Existing item - check if specs changed
+			existingSpec := convertDesignItemToSpec(existingItem)
+			currentSpecMap := upload.MapSpecForComparison(&existingSpec)
+			newSpecMap := upload.MapSpecForComparison(&spec)
+			if !upload.CompareSpecs(newSpecMap, currentSpecMap) {
+				shouldCreateRevision = true
+			}
+		}
+
+		if shouldCreateRevision {
+			rev := map[string]interface{}{
+				"design_item_id": item.ID,
+				"status":         item.Status,
+				"specs":          item.Specs,
+				"type":           item.Type,
+				"change_type":    "user",
+				"name":           "",
+				"user_id":        user.ID,
+			}
+			revs = append(revs, rev)
+		}
+	}
+
+	if len(revs) == 0 {
+		return nil
+	}
+
+	for attempt := 1; ; attempt++ {
+		affectedRows, err := client.InsertDesignItemRevs(ctx, revs)
+		if err == nil {
+			logger.Debug("Inserted %d revisions", affectedRows)
+			return nil
+		}
+		if !utils.IsUnsentError(err) {
+			return fmt.Errorf("failed to insert %d revisions: %w", len(revs), err)
+		}
+		logger.Warn("Failed to insert revisions (attempt %d/%d): %v", attempt, revisionInsertAttempts, err)
+		if attempt == revisionInsertAttempts {
+			return fmt.Errorf("failed to insert %d revisions: %w", len(revs), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to insert %d revisions: %w", len(revs), err)
+		case <-time.After(time.Duration(attempt) * time.Second):
+		}
+	}
+}
+
+// prepareSpecFile runs every check for one spec file and builds the items to
+// upsert. It returns either the prepared file or, when the file is done
+// without an upsert (invalid, unchanged, --validate-server), its result.
+func prepareSpecFile(ctx context.Context, client *graphql.Client, filePath string, opts specUploadOptions) (*preparedSpecFile, *upload.UploadResult) {
 	fileName := filepath.Base(filePath)
 
 	// Parse file path
 	parsed, err := upload.ParseFilePath(filePath)
 	if err != nil {
-		return upload.UploadResult{
+		return nil, &upload.UploadResult{
 			FilePath: filePath,
 			FileName: fileName,
 			Status:   upload.StatusSkipped,
@@ -210,7 +1113,7 @@ func uploadSingleSpecFile(ctx context.Context, client *graphql.Client, filePath,
 	// Parse CSV file
 	specs, err := upload.ParseSpecsCSV(filePath)
 	if err != nil {
-		return upload.UploadResult{
+		return nil, &upload.UploadResult{
 			FilePath: filePath,
 			FileName: fileName,
 			Status:   upload.StatusFailed,
@@ -219,8 +1122,16 @@ func uploadSingleSpecFile(ctx context.Context, client *graphql.Client, filePath,
 		}
 	}
 
+	return prepareSpecs(ctx, client, filePath, parsed, specs, opts)
+}
+
+// prepareSpecs is prepareSpecFile for specs that are already parsed, with
+// filePath only used to label the result
+func prepareSpecs(ctx context.Context, client *graphql.Client, filePath string, parsed *upload.ParsedFilePath, specs []upload.Spec, opts specUploadOptions) (*preparedSpecFile, *upload.UploadResult) {
+	fileName := filepath.Base(filePath)
+
 	if len(specs) == 0 {
-		return upload.UploadResult{
+		return nil, &upload.UploadResult{
 			FilePath: filePath,
 			FileName: fileName,
 			Status:   upload.StatusSkipped,
@@ -230,10 +1141,13 @@ func uploadSingleSpecFile(ctx context.Context, client *graphql.Client, filePath,
 
 	logger.Debug("Parsed %d specs from %s", len(specs), fileName)
 
+	// Drop rows that repeat an itemId; upserting both would be ambiguous
+	specs, duplicateSpecs := upload.FindDuplicateSpecs(specs)
+
 	// Get frame to validate and get IDs
 	frame, err := client.GetFrame(ctx, parsed.FileKey, parsed.FrameID)
 	if err != nil {
-		return upload.UploadResult{
+		return nil, &upload.UploadResult{
 			FilePath: filePath,
 			FileName: fileName,
 			Status:   upload.StatusFailed,
@@ -243,8 +1157,8 @@ func uploadSingleSpecFile(ctx context.Context, client *graphql.Client, filePath,
 	}
 
 	// Check frame status (matches SDK's inDesignFrame check)
-	if frame.Status == "design" {
-		return upload.UploadResult{
+	if !upload.FrameAcceptsUploads(frame.Status) {
+		return nil, &upload.UploadResult{
 			FilePath: filePath,
 			FileName: fileName,
 			Status:   upload.StatusFailed,
@@ -261,7 +1175,7 @@ func uploadSingleSpecFile(ctx context.Context, client *graphql.Client, filePath,
 	}
 
 	if len(nodeLinkIds) == 0 {
-		return upload.UploadResult{
+		return nil, &upload.UploadResult{
 			FilePath: filePath,
 			FileName: fileName,
 			Status:   upload.StatusFailed,
@@ -269,11 +1183,18 @@ func uploadSingleSpecFile(ctx context.Context, client *graphql.Client, filePath,
 		}
 	}
 
-	// Get existing design items for comparison
-	var existingItems []graphql.DesignItem
-	existingItems, err = client.ListDesignItemsByNodeLinkIds(ctx, parsed.FileKey, parsed.FrameID, nodeLinkIds)
+	// Get existing design items for comparison. Without them every item
+	// would look new, so statuses would be inferred again and unchanged
+	// rows re-sent; the file fails instead.
+	existingItems, err := client.ListDesignItemsByNodeLinkIds(ctx, parsed.FileKey, parsed.FrameID, nodeLinkIds)
 	if err != nil {
-		logger.Debug("Failed to get existing design items: %v", err)
+		return nil, &upload.UploadResult{
+			FilePath: filePath,
+			FileName: fileName,
+			Status:   upload.StatusFailed,
+			Error:    err,
+			Message:  fmt.Sprintf("Failed to get existing design items: %v", err),
+		}
 	}
 
 	// Build map of existing items by node_link_id
@@ -284,7 +1205,8 @@ func uploadSingleSpecFile(ctx context.Context, client *graphql.Client, filePath,
 
 	// Validate specs and determine status
 	var validSpecs []upload.ValidatedSpec
-	var invalidSpecs []upload.ValidatedSpec
+	invalidSpecs := duplicateSpecs
+	unchanged := 0
 
 	for _, spec := range specs {
 		existingItem, exists := existingMap[spec.NodeLinkID]
@@ -299,8 +1221,12 @@ func uploadSingleSpecFile(ctx context.Context, client *graphql.Client, filePath,
 			continue
 		}
 
-		// Determine status and validate
-		status, validationErrors := upload.DetermineSpecStatus(&spec, "")
+		// Determine status and validate, keeping the server's status where set
+		existingStatus := ""
+		if exists {
+			existingStatus = existingItem.Status
+		}
+		status, validationErrors := upload.DetermineSpecStatus(&spec, existingStatus)
 
 		// Check for changes (skip unchanged items)
 		currentSpecMap := upload.MapSpecForComparison(&spec)
@@ -312,10 +1238,14 @@ func uploadSingleSpecFile(ctx context.Context, client *graphql.Client, filePath,
 		}
 
 		hasChanged := !upload.CompareSpecs(currentSpecMap, previousSpecMap)
+		if exists && spec.IsReviewed != nil && *spec.IsReviewed != existingItem.IsReviewed {
+			hasChanged = true
+		}
 
-		// Skip unchanged items with same status
-		if !hasChanged && exists && existingItem.Status == status {
+		// Skip unchanged items with same status, unless everything is re-sent
+		if !hasChanged && exists && existingItem.Status == status && !opts.forceAll {
 			logger.Debug("Skipping unchanged spec: %s", spec.NodeLinkID)
+			unchanged++
 			continue
 		}
 
@@ -369,8 +1299,19 @@ func uploadSingleSpecFile(ctx context.Context, client *graphql.Client, filePath,
 
 		// Query to validate linked frames exist
 		linkedFrames, err := client.ListFramesByFrameLinkIds(ctx, parsed.FileKey, frameLinkIds)
+		if err != nil && opts.validateOnly {
+			// Checking linked frames is what --validate-server is for, so
+			// the file cannot be reported as valid without it
+			return nil, &upload.UploadResult{
+				FilePath: filePath,
+				FileName: fileName,
+				Status:   upload.StatusFailed,
+				Error:    err,
+				Message:  fmt.Sprintf("Failed to validate linked frames: %v", err),
+			}
+		}
 		if err != nil {
-			logger.Debug("Failed to validate linked frames: %v", err)
+			logger.Warn("Failed to validate linked frames: %v", err)
 		} else {
 			// Build map of existing frames
 			frameMap := make(map[string]bool)
@@ -384,7 +1325,7 @@ func uploadSingleSpecFile(ctx context.Context, client *graphql.Client, filePath,
 					if !frameMap[validSpecs[i].LinkedFrameID] {
 						validSpecs[i].IsValid = false
 						validSpecs[i].Errors = append(validSpecs[i].Errors,
-							fmt.Sprintf("Linked frame with ID \"%s\" not found", validSpecs[i].LinkedFrameID))
+							fmt.Sprintf("Linked frame %q not found in this file (the id is well-formed; check the frame exists and was synced to MoMorph)", validSpecs[i].LinkedFrameID))
 						// Move to invalid specs
 						invalidSpecs = append(invalidSpecs, validSpecs[i])
 					}
@@ -406,27 +1347,46 @@ func uploadSingleSpecFile(ctx context.Context, client *graphql.Client, filePath,
 	if len(invalidSpecs) > 0 {
 		logger.Debug("Found %d invalid specs", len(invalidSpecs))
 		for _, inv := range invalidSpecs {
-			logger.Debug("  - %s: %v", inv.NodeLinkID, inv.Errors)
+			logger.Debug("  - row %d, %s: %v", inv.Row, inv.NodeLinkID, inv.Errors)
+		}
+	}
+
+	// Strict mode: any invalid spec fails the whole file
+	if opts.strict && len(invalidSpecs) > 0 {
+		return nil, &upload.UploadResult{
+			FilePath: filePath,
+			FileName: fileName,
+			Status:   upload.StatusFailed,
+			Message:  fmt.Sprintf("%d invalid spec(s), nothing uploaded (--strict)", len(invalidSpecs)),
+			Details:  describeInvalidSpecs(invalidSpecs),
 		}
 	}
 
 	if len(validSpecs) == 0 {
 		if len(invalidSpecs) > 0 {
-			return upload.UploadResult{
+			return nil, &upload.UploadResult{
 				FilePath: filePath,
 				FileName: fileName,
 				Status:   upload.StatusFailed,
 				Message:  fmt.Sprintf("No valid specs to update (%d invalid)", len(invalidSpecs)),
+				Details:  describeInvalidSpecs(invalidSpecs),
 			}
 		}
-		return upload.UploadResult{
-			FilePath: filePath,
-			FileName: fileName,
-			Status:   upload.StatusSkipped,
-			Message:  "No changes detected",
+		return nil, &upload.UploadResult{
+			FilePath:  filePath,
+			FileName:  fileName,
+			Status:    upload.StatusSkipped,
+			Message:   "No changes detected",
+			Unchanged: unchanged,
 		}
 	}
 
+	// Validation run: report what the upsert would do and stop
+	if opts.validateOnly {
+		result := validatedSpecsResult(filePath, validSpecs, invalidSpecs, unchanged)
+		return nil, &result
+	}
+
 	// Prepare items for upsert
 	var items []map[string]interface{}
 	for _, validated := range validSpecs {
@@ -457,6 +1417,14 @@ func uploadSingleSpecFile(ctx context.Context, client *graphql.Client, filePath,
 			"status":          payload.Status,
 		}
 
+		// A blank "reviewed" cell keeps the server's flag; the upsert would
+		// otherwise reset it to the column default
+		if payload.IsReviewed != nil {
+			item["is_reviewed"] = *payload.IsReviewed
+		} else if existing, ok := existingMap[spec.NodeLinkID]; ok {
+			item["is_reviewed"] = existing.IsReviewed
+		}
+
 		if payload.Specs != nil {
 			specsJSON, _ := json.Marshal(payload.Specs)
 			item["specs"] = json.RawMessage(specsJSON)
@@ -465,88 +1433,87 @@ func uploadSingleSpecFile(ctx context.Context, client *graphql.Client, filePath,
 		items = append(items, item)
 	}
 
-	// Upsert design items
-	savedItems, err := client.UpsertDesignItemSpecs(ctx, items)
-	if err != nil {
-		return upload.UploadResult{
-			FilePath: filePath,
-			FileName: fileName,
-			Status:   upload.StatusFailed,
-			Error:    err,
-			Message:  fmt.Sprintf("Failed to upsert specs: %v", err),
+	return &preparedSpecFile{
+		filePath:     filePath,
+		frameKey:     parsed.FileKey + "/" + parsed.FrameID,
+		items:        items,
+		validSpecs:   validSpecs,
+		invalidSpecs: invalidSpecs,
+		existingMap:  existingMap,
+		unchanged:    unchanged,
+	}, nil
+}
+
+// validatedSpecsResult describes what uploading a file would do, for
+// --validate-server. The status is the one the real upload would report.
+func validatedSpecsResult(filePath string, validSpecs, invalidSpecs []upload.ValidatedSpec, unchanged int) upload.UploadResult {
+	newCount := 0
+	for _, vs := range validSpecs {
+		if vs.IsNew {
+			newCount++
 		}
 	}
 
-	logger.Debug("Upserted %d design items", len(savedItems))
+	message := fmt.Sprintf("Would upload %d specs (%d new, %d changed)", len(validSpecs), newCount, len(validSpecs)-newCount)
+	if len(invalidSpecs) > 0 {
+		message += fmt.Sprintf(" (%d invalid)", len(invalidSpecs))
+	}
+	if unchanged > 0 {
+		message += fmt.Sprintf(" (%d unchanged)", unchanged)
+	}
 
-	// Create revisions if actor is available
-	if actor != "" {
-		user, err := client.GetMorpheusUserByEmail(ctx, actor)
-		if err == nil && user != nil {
-			// Prepare revision entries for new AND changed items
-			var revs []map[string]interface{}
-			for _, item := range savedItems {
-				existingItem, existed := existingMap[item.NodeLinkID]
-
-				shouldCreateRevision := false
-				if !existed {
-					// New item - always create revision
-					shouldCreateRevision = true
-				} else {
-					// Existing item - check if specs changed
-					existingSpec := convertDesignItemToSpec(existingItem)
-					currentSpecMap := upload.MapSpecForComparison(&existingSpec)
-
-					// Find the validated spec to get current values
-					for _, vs := range validSpecs {
-						if vs.NodeLinkID == item.NodeLinkID {
-							newSpecMap := upload.MapSpecForComparison(&vs.Spec)
-							if !upload.CompareSpecs(newSpecMap, currentSpecMap) {
-								shouldCreateRevision = true
-							}
-							break
-						}
-					}
-				}
+	return upload.UploadResult{
+		FilePath:  filePath,
+		FileName:  filepath.Base(filePath),
+		Status:    upload.StatusSuccess,
+		Message:   message,
+		Details:   describeInvalidSpecs(invalidSpecs),
+		Unchanged: unchanged,
+	}
+}
 
-				if shouldCreateRevision {
-					rev := map[string]interface{}{
-						"design_item_id": item.ID,
-						"status":         item.Status,
-						"specs":          item.Specs,
-						"type":           item.Type,
-						"change_type":    "user",
-						"name":           "",
-						"user_id":        user.ID,
-					}
-					revs = append(revs, rev)
-				}
-			}
+// checkTargetFrames looks up the frame of every file once and describes the
+// frames that would reject the upload: missing frames and frames in 'design'
+// status. It returns nil when all frames accept uploads.
+func checkTargetFrames(ctx context.Context, client *graphql.Client, files []string) []string {
+	var blocked []string
+	checked := make(map[string]bool)
 
-			if len(revs) > 0 {
-				affectedRows, err := client.InsertDesignItemRevs(ctx, revs)
-				if err != nil {
-					logger.Warn("Failed to insert revisions: %v", err)
-				} else {
-					logger.Debug("Inserted %d revisions", affectedRows)
-				}
-			}
-		} else {
-			logger.Debug("Could not get user for revision tracking: %v", err)
+	for _, file := range files {
+		parsed, err := upload.ParseFilePath(file)
+		if err != nil {
+			continue // already reported by ValidateFiles
 		}
-	}
+		key := parsed.FileKey + "/" + parsed.FrameID
+		if checked[key] {
+			continue
+		}
+		checked[key] = true
 
-	message := fmt.Sprintf("Uploaded %d specs", len(savedItems))
-	if len(invalidSpecs) > 0 {
-		message += fmt.Sprintf(" (%d invalid)", len(invalidSpecs))
+		frame, err := client.GetFrame(ctx, parsed.FileKey, parsed.FrameID)
+		if err != nil {
+			blocked = append(blocked, fmt.Sprintf("%s (%s): %v", parsed.FrameID, filepath.Base(file), err))
+			continue
+		}
+		if !upload.FrameAcceptsUploads(frame.Status) {
+			blocked = append(blocked, fmt.Sprintf("%s %q (%s): frame is in '%s' status", parsed.FrameID, frame.Name, filepath.Base(file), frame.Status))
+		}
 	}
 
-	return upload.UploadResult{
-		FilePath: filePath,
-		FileName: fileName,
-		Status:   upload.StatusSuccess,
-		Message:  message,
+	return blocked
+}
+
+// describeInvalidSpecs formats invalid specs as one line per row for display,
+// e.g. Row 42 (itemId abc): name is 312 characters (max 255): "Lorem ipsum..."
+func describeInvalidSpecs(invalidSpecs []upload.ValidatedSpec) []string {
+	sorted := append([]upload.ValidatedSpec(nil), invalidSpecs...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Row < sorted[j].Row })
+
+	var lines []string
+	for _, inv := range sorted {
+		lines = append(lines, fmt.Sprintf("Row %d (itemId %s): %s", inv.Row, inv.NodeLinkID, strings.Join(inv.Errors, "; ")))
 	}
+	return lines
 }
 
 // convertDesignItemToSpec converts a GraphQL DesignItem to a Spec for comparison
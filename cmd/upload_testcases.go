@@ -2,14 +2,13 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"os"
-	"os/signal"
 	"path/filepath"
-	"syscall"
 
 	"github.com/momorph/cli/internal/auth"
 	"github.com/momorph/cli/internal/graphql"
+	"github.com/momorph/cli/internal/i18n"
 	"github.com/momorph/cli/internal/logger"
 	"github.com/momorph/cli/internal/upload"
 	"github.com/spf13/cobra"
@@ -20,8 +19,18 @@ var (
 	tcUploadRecursive bool
 	tcUploadDryRun    bool
 	tcUploadContinue  bool
+	tcUploadMode      string
 )
 
+// testcaseUploadOptions controls how individual test case files are uploaded
+type testcaseUploadOptions struct {
+	continueOnError bool   // keep going after a file fails
+	mode            string // upload.TestcaseModeReplace or upload.TestcaseModeAppend
+	// journal records completed files; with --resume it holds the files of
+	// the earlier run, which are skipped
+	journal *upload.Journal
+}
+
 // CSV columns are mapped to test case fields:
 //
 //	TC_ID -> ID, Steps -> step, Category -> category, Page_Name -> page_name,
@@ -51,8 +60,15 @@ Files must follow the path pattern:
   momorph upload testcases ".momorph/testcases/**/*.csv"
 
   # Dry run (show what would be uploaded)
-  momorph upload testcases --dry-run .momorph/testcases/**/*.csv`,
-	RunE: runUploadTestcases,
+  momorph upload testcases --dry-run .momorph/testcases/**/*.csv
+
+  # Add or update test cases by TC_ID, keeping the others on the server
+  momorph upload testcases --mode append .momorph/testcases/xxx/yyy.csv
+
+  # Skip files already uploaded if an earlier run was interrupted
+  momorph upload testcases --resume --dir .momorph/testcases/ -r`,
+	ValidArgsFunction: completeUploadFiles("testcases"),
+	RunE:              runUploadTestcases,
 }
 
 func init() {
@@ -60,6 +76,7 @@ func init() {
 	uploadTestcasesCmd.Flags().BoolVarP(&tcUploadRecursive, "recursive", "r", false, "Search directories recursively")
 	uploadTestcasesCmd.Flags().BoolVar(&tcUploadDryRun, "dry-run", false, "Show what would be uploaded without actually uploading")
 	uploadTestcasesCmd.Flags().BoolVar(&tcUploadContinue, "continue-on-error", false, "Continue uploading remaining files if one fails")
+	uploadTestcasesCmd.Flags().StringVar(&tcUploadMode, "mode", upload.TestcaseModeReplace, "How to treat test cases already on the server: replace or append (merge by TC_ID)")
 	uploadCmd.AddCommand(uploadTestcasesCmd)
 }
 
@@ -69,20 +86,22 @@ func runUploadTestcases(cmd *cobra.Command, args []string) error {
 	defer cancel()
 
 	// Signal handling for graceful cancellation
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	go func() {
-		<-sigChan
-		fmt.Println("\n\n✗ Upload cancelled")
-		cancel()
-		os.Exit(0)
-	}()
+	stopSignals := cancelOnSignal(cancel)
+	defer stopSignals()
+	defer startHTTPTimings()()
+
+	if tcUploadMode != upload.TestcaseModeReplace && tcUploadMode != upload.TestcaseModeAppend {
+		return fmt.Errorf("invalid --mode %q (must be one of: replace, append)", tcUploadMode)
+	}
+	if err := validateUploadOutput(setFlags(cmd, "dry-run")...); err != nil {
+		return err
+	}
 
 	// Check authentication
 	if !auth.IsAuthenticated() {
-		fmt.Println("✗ Not authenticated")
-		fmt.Println("\nRun 'momorph login' to authenticate before uploading")
-		return nil
+		fmt.Println(i18n.T("auth.not_authenticated"))
+		fmt.Println("\n" + i18n.T("auth.login_before_upload"))
+		return errNotAuthenticated()
 	}
 
 	// Resolve files
@@ -92,8 +111,12 @@ func runUploadTestcases(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(files) == 0 {
-		fmt.Println("No CSV files found to upload")
-		fmt.Println("\nMake sure files are in the correct path format:")
+		if uploadJSON() {
+			printUploadSummaryJSON(nil)
+			return nil
+		}
+		fmt.Println(i18n.T("upload.no_files"))
+		fmt.Println("\n" + i18n.T("upload.path_hint"))
 		fmt.Println("  .momorph/testcases/{file_key}/{frame_id}-{frame_name}.csv")
 		return nil
 	}
@@ -103,12 +126,16 @@ func runUploadTestcases(cmd *cobra.Command, args []string) error {
 
 	// Print skipped files
 	for _, s := range skipped {
-		fmt.Printf("  [SKIPPED] %s\n", s.FileName)
-		fmt.Printf("    Reason: %s\n", s.Message)
+		infof("  [SKIPPED] %s\n", s.FileName)
+		infof("    Reason: %s\n", s.Message)
 	}
 
 	if len(validFiles) == 0 {
-		fmt.Println("\nNo valid files to upload")
+		if uploadJSON() {
+			printUploadSummaryJSON(skipped)
+			return nil
+		}
+		fmt.Println("\n" + i18n.T("upload.no_valid_files"))
 		return nil
 	}
 
@@ -131,21 +158,41 @@ func runUploadTestcases(cmd *cobra.Command, args []string) error {
 		logger.Error("Failed to create GraphQL client", err)
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
+	client.SetRateLimit(uploadRateLimit)
+
+	// Fail before the first file if the session has been revoked
+	if err := checkSessionBeforeUpload(ctx); err != nil {
+		return err
+	}
+
+	journal, err := openUploadJournal("testcases")
+	if err != nil {
+		return err
+	}
 
 	// Upload files
-	fmt.Printf("\nUploading %d test case file(s)...\n", len(validFiles))
-	results := uploadTestcaseFiles(ctx, client, validFiles, tcUploadContinue)
+	infof("\n%s\n", i18n.T("upload.uploading_testcases", len(validFiles)))
+	opts := testcaseUploadOptions{
+		continueOnError: tcUploadContinue,
+		mode:            tcUploadMode,
+		journal:         journal,
+	}
+	results := uploadTestcaseFiles(ctx, client, validFiles, opts)
+	closeUploadJournal(ctx, journal, results)
 
 	// Combine with skipped files
 	allResults := append(skipped, results...)
 
 	// Display summary
 	displayUploadSummary(allResults)
+	if ctx.Err() != nil {
+		return errUploadInterrupted()
+	}
 
 	return nil
 }
 
-func uploadTestcaseFiles(ctx context.Context, client *graphql.Client, files []string, continueOnError bool) []upload.UploadResult {
+func uploadTestcaseFiles(ctx context.Context, client *graphql.Client, files []string, opts testcaseUploadOptions) []upload.UploadResult {
 	var results []upload.UploadResult
 
 	for i, file := range files {
@@ -156,31 +203,44 @@ func uploadTestcaseFiles(ctx context.Context, client *graphql.Client, files []st
 		default:
 		}
 
-		fileName := filepath.Base(file)
-		fmt.Printf("  [%d/%d] %s ", i+1, len(files), fileName)
+		// In quiet mode the file line is only printed if the upload fails
+		fileLine := fmt.Sprintf("  [%d/%d] %s ", i+1, len(files), filepath.Base(file))
+		infof("%s", fileLine)
+
+		if opts.journal != nil && opts.journal.IsCompleted(file) {
+			infoln(".... already uploaded")
+			results = append(results, resumedResult(file))
+			continue
+		}
 
-		result := uploadSingleTestcaseFile(ctx, client, file)
+		result := uploadSingleTestcaseFile(ctx, client, file, opts)
 		results = append(results, result)
 
 		switch result.Status {
 		case upload.StatusSuccess:
-			fmt.Println(".... done")
+			markUploadCompleted(opts.journal, file)
+			infoln(".... done")
 		case upload.StatusFailed:
-			fmt.Println(".... failed")
-			fmt.Printf("    Error: %s\n", result.Message)
-			if !continueOnError {
+			if !uploadJSON() {
+				if quietMode {
+					fmt.Print(fileLine)
+				}
+				fmt.Println(".... failed")
+				fmt.Printf("    Error: %s\n", result.Message)
+			}
+			if !opts.continueOnError {
 				return results
 			}
 		case upload.StatusSkipped:
-			fmt.Println(".... skipped")
-			fmt.Printf("    Reason: %s\n", result.Message)
+			infoln(".... skipped")
+			infof("    Reason: %s\n", result.Message)
 		}
 	}
 
 	return results
 }
 
-func uploadSingleTestcaseFile(ctx context.Context, client *graphql.Client, filePath string) upload.UploadResult {
+func uploadSingleTestcaseFile(ctx context.Context, client *graphql.Client, filePath string, opts testcaseUploadOptions) upload.UploadResult {
 	fileName := filepath.Base(filePath)
 
 	// Parse file path
@@ -218,13 +278,56 @@ func uploadSingleTestcaseFile(ctx context.Context, client *graphql.Client, fileP
 
 	logger.Debug("Parsed %d test cases from %s", len(content.TestCases), fileName)
 
+	// Get frame to validate status and get internal ID
+	frame, err := client.GetFrame(ctx, parsed.FileKey, parsed.FrameID)
+	if err != nil {
+		return upload.UploadResult{
+			FilePath: filePath,
+			FileName: fileName,
+			Status:   upload.StatusFailed,
+			Error:    err,
+			Message:  fmt.Sprintf("Frame not found: %v", err),
+		}
+	}
+
+	// Same guard as the specs upload: design frames are not ready for test cases
+	if !upload.FrameAcceptsUploads(frame.Status) {
+		return upload.UploadResult{
+			FilePath: filePath,
+			FileName: fileName,
+			Status:   upload.StatusFailed,
+			Message:  "Cannot upload test cases to frame in 'design' status",
+		}
+	}
+
 	// Check if test cases already exist for this frame
 	existingTestCases, err := client.GetFrameTestCases(ctx, parsed.FileKey, parsed.FrameID)
 	if err != nil {
 		logger.Debug("No existing test cases found: %v", err)
 	}
 
+	message := fmt.Sprintf("Uploaded %d test cases", len(content.TestCases))
+
 	if len(existingTestCases) > 0 {
+		// Append mode merges into the existing content instead of replacing it
+		if opts.mode == upload.TestcaseModeAppend {
+			var existingContent upload.TestCaseContent
+			if len(existingTestCases[0].Content) > 0 {
+				if err := json.Unmarshal(existingTestCases[0].Content, &existingContent); err != nil {
+					return upload.UploadResult{
+						FilePath: filePath,
+						FileName: fileName,
+						Status:   upload.StatusFailed,
+						Error:    err,
+						Message:  fmt.Sprintf("Failed to read existing test cases for append: %v", err),
+					}
+				}
+			}
+			uploaded := len(content.TestCases)
+			content.TestCases = upload.MergeTestCases(existingContent.TestCases, content.TestCases)
+			message = fmt.Sprintf("Appended %d test cases (%d total)", uploaded, len(content.TestCases))
+		}
+
 		// Update existing test case
 		logger.Debug("Updating existing test case ID: %d", existingTestCases[0].ID)
 		_, err = client.UpdateFrameTestcase(ctx, existingTestCases[0].ID, content)
@@ -238,18 +341,6 @@ func uploadSingleTestcaseFile(ctx context.Context, client *graphql.Client, fileP
 			}
 		}
 	} else {
-		// Get frame to get internal ID
-		frame, err := client.GetFrame(ctx, parsed.FileKey, parsed.FrameID)
-		if err != nil {
-			return upload.UploadResult{
-				FilePath: filePath,
-				FileName: fileName,
-				Status:   upload.StatusFailed,
-				Error:    err,
-				Message:  fmt.Sprintf("Frame not found: %v", err),
-			}
-		}
-
 		logger.Debug("Creating new test case for frame ID: %d", frame.ID)
 
 		// Insert new test case
@@ -269,30 +360,49 @@ func uploadSingleTestcaseFile(ctx context.Context, client *graphql.Client, fileP
 		FilePath: filePath,
 		FileName: fileName,
 		Status:   upload.StatusSuccess,
-		Message:  fmt.Sprintf("Uploaded %d test cases", len(content.TestCases)),
+		Message:  message,
 	}
 }
 
+// displayUploadSummary prints the upload summary. In quiet mode only the
+// closing line with the counts is printed.
 func displayUploadSummary(results []upload.UploadResult) {
+	if uploadJSON() {
+		printUploadSummaryJSON(results)
+		return
+	}
 	summary := upload.NewUploadSummary(results)
 
+	// Quiet mode keeps only the one-line counts, without the box
+	if quietMode {
+		fmt.Println(uploadOutcomeLine(summary))
+		return
+	}
+
 	fmt.Println()
 	fmt.Println("─────────────────────────────────────────")
-	fmt.Println("Summary")
+	fmt.Println(i18n.T("upload.summary"))
 	fmt.Println("─────────────────────────────────────────")
-	fmt.Printf("  Total files:  %d\n", summary.Total)
-	fmt.Printf("  Success:      %d\n", summary.Success)
-	fmt.Printf("  Failed:       %d\n", summary.Failed)
-	fmt.Printf("  Skipped:      %d\n", summary.Skipped)
+	fmt.Println(i18n.T("upload.summary_total", summary.Total))
+	fmt.Println(i18n.T("upload.summary_success", summary.Success))
+	fmt.Println(i18n.T("upload.summary_failed", summary.Failed))
+	fmt.Println(i18n.T("upload.summary_skipped", summary.Skipped))
+	if summary.Unchanged > 0 {
+		fmt.Println(i18n.T("upload.summary_unchanged", summary.Unchanged))
+	}
 	fmt.Println("─────────────────────────────────────────")
 
 	// Show status message
+	fmt.Println("\n" + uploadOutcomeLine(summary))
+}
+
+// uploadOutcomeLine returns the closing status line of an upload summary
+func uploadOutcomeLine(summary *upload.UploadSummary) string {
 	if summary.Failed == 0 && summary.Skipped == 0 {
-		fmt.Printf("\n✓ Successfully uploaded %d file(s)\n", summary.Success)
-	} else if summary.Success == 0 {
-		fmt.Println("\n✗ All uploads failed or were skipped")
-	} else {
-		fmt.Printf("\n⚠ Uploaded %d file(s), %d failed, %d skipped\n",
-			summary.Success, summary.Failed, summary.Skipped)
+		return i18n.T("upload.all_succeeded", summary.Success)
+	}
+	if summary.Success == 0 {
+		return i18n.T("upload.all_failed")
 	}
+	return i18n.T("upload.partial", summary.Success, summary.Failed, summary.Skipped)
 }
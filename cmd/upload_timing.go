@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/momorph/cli/internal/utils"
+)
+
+// maxTimingEndpoints is how many endpoints the timing summary lists
+const maxTimingEndpoints = 5
+
+// httpTimings aggregates the HTTP requests made during an upload run, to tell
+// a slow server apart from many small round trips
+type httpTimings struct {
+	mu        sync.Mutex
+	start     time.Time
+	requests  int
+	failed    int
+	network   time.Duration
+	endpoints map[string]*endpointTiming
+}
+
+// endpointTiming aggregates the requests to one endpoint
+type endpointTiming struct {
+	name    string
+	count   int
+	total   time.Duration
+	slowest time.Duration
+}
+
+// startHTTPTimings collects the timing of every HTTP request when --verbose
+// or --debug is set. The returned func stops collecting and prints the
+// summary to stderr, so it does not mix with --output json.
+func startHTTPTimings() func() {
+	if !uploadVerbose && !debugMode {
+		return func() {}
+	}
+
+	timings := &httpTimings{
+		start:     time.Now(),
+		endpoints: make(map[string]*endpointTiming),
+	}
+	utils.SetRequestObserver(timings.record)
+	return func() {
+		utils.SetRequestObserver(nil)
+		timings.print(os.Stderr)
+	}
+}
+
+// record adds one request. Uploads send their requests one at a time, but
+// the request observer may be called from any goroutine, so it locks anyway.
+func (t *httpTimings) record(timing utils.RequestTiming) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.requests++
+	if timing.Err != nil || timing.Status >= 400 {
+		t.failed++
+	}
+	t.network += timing.Duration
+
+	name := timing.Method + " " + timing.Endpoint
+	endpoint, ok := t.endpoints[name]
+	if !ok {
+		endpoint = &endpointTiming{name: name}
+		t.endpoints[name] = endpoint
+	}
+	endpoint.count++
+	endpoint.total += timing.Duration
+	if timing.Duration > endpoint.slowest {
+		endpoint.slowest = timing.Duration
+	}
+}
+
+// print writes the summary: request count, network time against elapsed
+// time, and the endpoints that took the most time in total
+func (t *httpTimings) print(w io.Writer) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.requests == 0 {
+		return
+	}
+
+	elapsed := time.Since(t.start)
+	fmt.Fprintf(w, "\nHTTP timing: %d request(s)", t.requests)
+	if t.failed > 0 {
+		fmt.Fprintf(w, " (%d failed)", t.failed)
+	}
+	fmt.Fprintf(w, ", %v network time in %v elapsed\n", roundTiming(t.network), roundTiming(elapsed))
+
+	endpoints := make([]*endpointTiming, 0, len(t.endpoints))
+	for _, endpoint := range t.endpoints {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].total > endpoints[j].total })
+
+	slowest := endpoints[0]
+	for _, endpoint := range endpoints {
+		if endpoint.slowest > slowest.slowest {
+			slowest = endpoint
+		}
+	}
+	fmt.Fprintf(w, "  Slowest request: %s (%v)\n", slowest.name, roundTiming(slowest.slowest))
+
+	if len(endpoints) > maxTimingEndpoints {
+		endpoints = endpoints[:maxTimingEndpoints]
+	}
+
+	for _, endpoint := range endpoints {
+		avg := endpoint.total / time.Duration(endpoint.count)
+		fmt.Fprintf(w, "  %-48s %4d × %-8v avg, %v total\n", endpoint.name, endpoint.count, roundTiming(avg), roundTiming(endpoint.total))
+	}
+}
+
+// roundTiming rounds a duration for display
+func roundTiming(d time.Duration) time.Duration {
+	if d >= time.Second {
+		return d.Round(10 * time.Millisecond)
+	}
+	return d.Round(time.Millisecond)
+}
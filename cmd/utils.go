@@ -1,6 +1,12 @@
 package cmd
 
-import "strings"
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
 
 // maskEmail partially masks the local part and shows domain
 // e.g., john@example.com -> j***n@example.com
@@ -29,3 +35,40 @@ func maskEmail(email string) string {
 	// Show first and last char, mask middle
 	return string(localPart[0]) + "***" + string(localPart[len(localPart)-1]) + "@" + domain
 }
+
+// infoln prints an informational line (progress, hints) unless --quiet is
+// set or stdout is reserved for JSON. Errors, warnings and final results are
+// printed with fmt directly.
+func infoln(a ...interface{}) {
+	if !quietMode && !uploadJSON() {
+		fmt.Println(a...)
+	}
+}
+
+// infof is the Printf form of infoln
+func infof(format string, a ...interface{}) {
+	if !quietMode && !uploadJSON() {
+		fmt.Printf(format, a...)
+	}
+}
+
+// warnln prints a warning to stdout, or to stderr when stdout is reserved
+// for JSON output
+func warnln(a ...interface{}) {
+	if uploadJSON() {
+		fmt.Fprintln(os.Stderr, a...)
+		return
+	}
+	fmt.Println(a...)
+}
+
+// setFlags returns the names among flags that were set on cmd's command line
+func setFlags(cmd *cobra.Command, flags ...string) []string {
+	var set []string
+	for _, name := range flags {
+		if cmd.Flags().Changed(name) {
+			set = append(set, name)
+		}
+	}
+	return set
+}
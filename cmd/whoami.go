@@ -2,25 +2,31 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
 	"github.com/momorph/cli/internal/auth"
+	clierrors "github.com/momorph/cli/internal/errors"
 	"github.com/momorph/cli/internal/logger"
 	"github.com/spf13/cobra"
 )
 
+var whoamiRefresh bool
+
 var whoamiCmd = &cobra.Command{
 	Use:   "whoami",
 	Short: "Show current authenticated user information",
-	Example: `  momorph whoami            # Show current user info
+	Example: `  momorph whoami            # Show current user info (cached, works offline)
+  momorph whoami --refresh  # Fetch live info, including connected accounts
   momorph whoami --debug    # Show with debug information`,
 	RunE: runWhoami,
 }
 
 func init() {
+	whoamiCmd.Flags().BoolVar(&whoamiRefresh, "refresh", false, "Fetch user information from MoMorph instead of the local cache")
 	rootCmd.AddCommand(whoamiCmd)
 }
 
@@ -55,23 +61,48 @@ func runWhoami(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		fmt.Println("✗ Not authenticated")
 		fmt.Println("\nRun 'momorph login' to authenticate with GitHub and MoMorph")
-		return nil
+		return errNotAuthenticated()
 	}
 
 	// Check if token is valid
 	if !token.IsValid() {
 		fmt.Println("✗ Token invalid")
 		fmt.Println("\nRun 'momorph login' to reauthenticate")
-		return nil
+		return clierrors.NewAuthError(nil, "stored token is invalid").AsReported()
 	}
 
-	// Fetch fresh user info from MoMorph API
-	user, err := auth.GetMoMorphUser(ctx, token.GitHubToken)
-	if err != nil {
-		logger.Error("Failed to get user info", err)
-		fmt.Println("✗ Failed to fetch user information")
-		fmt.Println("\nRun 'momorph login' to reauthenticate")
-		return nil
+	// Show the profile cached at login unless a live lookup is requested;
+	// tokens saved by older versions have no cache and fall through
+	cached := !whoamiRefresh && token.HasCachedUser()
+	var user *auth.MoMorphUser
+	if cached {
+		user = &auth.MoMorphUser{
+			Email:     token.Email,
+			Username:  token.Email,
+			CreatedAt: token.CreatedAt,
+			TimeZone:  token.TimeZone,
+		}
+	} else {
+		// Fetch fresh user info from MoMorph API
+		user, err = auth.GetMoMorphUser(ctx, token.GitHubToken)
+		if err != nil {
+			logger.Error("Failed to get user info", err)
+			fmt.Println("✗ Failed to fetch user information")
+			if errors.Is(err, auth.ErrInvalidGitHubToken) || errors.Is(err, auth.ErrMoMorphAccessDenied) {
+				fmt.Println("\nRun 'momorph login' to reauthenticate")
+				return clierrors.NewAuthError(err, "MoMorph rejected the session")
+			}
+			if token.HasCachedUser() {
+				fmt.Println("\nRun 'momorph whoami' without --refresh to show the cached profile")
+			}
+			return clierrors.NewNetworkError(err, "failed to fetch user information")
+		}
+
+		// Keep the cache current for the next offline lookup
+		token.SetUser(user)
+		if err := auth.SaveToken(token); err != nil {
+			logger.Warn("Failed to update cached user info: %v", err)
+		}
 	}
 
 	// Define styles
@@ -123,6 +154,10 @@ func runWhoami(cmd *cobra.Command, args []string) error {
 		fmt.Println(t.String())
 	}
 
+	if cached {
+		fmt.Println(lipgloss.NewStyle().Faint(true).Render("Cached at login. Run 'momorph whoami --refresh' for live data and connected accounts."))
+	}
+
 	fmt.Println()
 	return nil
 }
@@ -6,6 +6,7 @@ require (
 	github.com/99designs/keyring v1.2.2
 	github.com/adrg/xdg v0.4.0
 	github.com/charmbracelet/lipgloss v0.9.1
+	github.com/muesli/termenv v0.15.2
 	github.com/rs/zerolog v1.31.0
 	github.com/spf13/cobra v1.10.2
 )
@@ -24,7 +25,6 @@ require (
 	github.com/mattn/go-runewidth v0.0.15 // indirect
 	github.com/mtibben/percent v0.2.1 // indirect
 	github.com/muesli/reflow v0.3.0 // indirect
-	github.com/muesli/termenv v0.15.2 // indirect
 	github.com/rivo/uniseg v0.4.6 // indirect
 	github.com/spf13/pflag v1.0.9 // indirect
 	golang.org/x/sys v0.40.0 // indirect
@@ -2,7 +2,6 @@ package api
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -26,6 +25,9 @@ func NewClient() (*Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
+	if err := cfg.ValidateAPIEndpoint(); err != nil {
+		return nil, err
+	}
 
 	return &Client{
 		baseURL:    cfg.GetAPIEndpoint(),
@@ -60,17 +62,14 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 	req.Header.Set("x-github-token", token.GitHubToken)
 
 	// Set Authorization header based on environment
-	if c.config.IsStaging() {
-		// Staging: Use Basic Auth
-		if c.config.HasBasicAuth() {
-			credentials := c.config.BasicAuthUsername + ":" + c.config.BasicAuthPassword
-			encoded := base64.StdEncoding.EncodeToString([]byte(credentials))
-			req.Header.Set("Authorization", "Basic "+encoded)
-		} else {
-			return nil, fmt.Errorf("staging environment requires MOMORPH_BASIC_AUTH_USERNAME and MOMORPH_BASIC_AUTH_PASSWORD")
-		}
+	// Staging: Bearer token or Basic Auth; Production: x-github-token is sufficient
+	authHeader, err := c.config.AuthorizationHeader()
+	if err != nil {
+		return nil, err
+	}
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
 	}
-	// Production: x-github-token header is sufficient, no Bearer token needed
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
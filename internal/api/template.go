@@ -5,8 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
 	"runtime"
+	"strings"
+	"time"
 
+	"github.com/momorph/cli/internal/config"
 	"github.com/momorph/cli/internal/logger"
 	"github.com/momorph/cli/internal/version"
 )
@@ -17,6 +21,23 @@ type TemplateMetadata struct {
 	DownloadURL string `json:"url"`       // Presigned URL
 	ExpiresIn   int    `json:"expiresIn"` // URL expiration in seconds
 	Cached      bool   `json:"cached"`    // Whether response was cached
+	// FetchedAt is when the metadata was requested, the start of ExpiresIn
+	FetchedAt time.Time `json:"-"`
+}
+
+// ExpiresAt returns when the presigned URL expires, or the zero time if the
+// API did not say
+func (t *TemplateMetadata) ExpiresAt() time.Time {
+	if t.ExpiresIn <= 0 || t.FetchedAt.IsZero() {
+		return time.Time{}
+	}
+	return t.FetchedAt.Add(time.Duration(t.ExpiresIn) * time.Second)
+}
+
+// ExpiresWithin reports whether the presigned URL expires within d from now
+func (t *TemplateMetadata) ExpiresWithin(d time.Duration) bool {
+	expiresAt := t.ExpiresAt()
+	return !expiresAt.IsZero() && time.Until(expiresAt) < d
 }
 
 // APIErrorResponse represents an error response from the API
@@ -25,44 +46,48 @@ type APIErrorResponse struct {
 	Key     string `json:"key"`
 }
 
-// GetProjectTemplate retrieves template metadata for the specified AI tool.
-// If tag is non-empty it is sent as the version parameter; otherwise the
-// version is auto-detected (stable for production builds, latest for dev).
-func (c *Client) GetProjectTemplate(ctx context.Context, aiTool string, tag string) (*TemplateMetadata, error) {
-	// Validate AI tool
-	validTools := map[string]bool{
-		"copilot":  true,
-		"cursor":   true,
-		"claude":   true,
-		"windsurf": true,
-		"gemini":   true,
+// templateVersionParam returns the version query parameter for tag: the tag
+// itself when set, otherwise "stable" for production releases and "latest"
+// for development builds
+func templateVersionParam(tag string) string {
+	if tag != "" {
+		return tag
 	}
-	if !validTools[aiTool] {
-		return nil, fmt.Errorf("invalid AI tool: %s (must be one of: copilot, cursor, claude, windsurf, gemini)", aiTool)
+	if version.Version == "" || version.Version == "dev" {
+		return "latest"
 	}
+	return "stable"
+}
 
-	// Determine shell based on OS
+// templatePresignPath builds the BFF presign path with its query parameters
+// Format: /g/bff/api/project-template/presign?agent=copilot&shell=sh&version=stable
+// version can be: stable (production release), latest (including pre-releases)
+// or a user-supplied --tag, so the query is escaped
+func templatePresignPath(aiTool string, tag string) string {
 	// API accepts: sh (Unix/Linux/macOS) or ps (PowerShell/Windows)
 	shell := "sh"
 	if runtime.GOOS == "windows" {
 		shell = "ps"
 	}
 
-	// Determine version parameter
-	// Use caller-supplied tag when provided; otherwise default to "stable"
-	// for production releases and "latest" for development builds.
-	versionParam := tag
-	if versionParam == "" {
-		versionParam = "stable"
-		if version.Version == "" || version.Version == "dev" {
-			versionParam = "latest"
-		}
+	query := url.Values{}
+	query.Set("agent", aiTool)
+	query.Set("shell", shell)
+	query.Set("version", templateVersionParam(tag))
+	return "/g/bff/api/project-template/presign?" + query.Encode()
+}
+
+// GetProjectTemplate retrieves template metadata for the specified AI tool.
+// If tag is non-empty it is sent as the version parameter; otherwise the
+// version is auto-detected (stable for production builds, latest for dev).
+func (c *Client) GetProjectTemplate(ctx context.Context, aiTool string, tag string) (*TemplateMetadata, error) {
+	// Validate AI tool
+	if !config.IsValidAITool(aiTool) {
+		return nil, fmt.Errorf("invalid AI tool: %s (must be one of: %s)", aiTool, strings.Join(config.AITools, ", "))
 	}
+	fetchedAt := time.Now()
 
-	// Build path with query parameters for BFF endpoint
-	// Format: /g/bff/api/project-template/presign?agent=copilot&shell=sh&version=stable
-	// version can be: stable (production release) or latest (including pre-releases)
-	path := fmt.Sprintf("/g/bff/api/project-template/presign?agent=%s&shell=%s&version=%s", aiTool, shell, versionParam)
+	path := templatePresignPath(aiTool, tag)
 
 	// Make request
 	resp, err := c.Get(ctx, path)
@@ -90,7 +115,7 @@ func (c *Client) GetProjectTemplate(ctx context.Context, aiTool string, tag stri
 				if tag != "" {
 					return nil, fmt.Errorf("template version %q not found for agent=%s", tag, aiTool)
 				}
-				return nil, fmt.Errorf("template not available for agent=%s (version=%s)\nPlease try again later or contact the MoMorph team", aiTool, versionParam)
+				return nil, fmt.Errorf("template not available for agent=%s (version=%s)\nPlease try again later or contact the MoMorph team", aiTool, templateVersionParam(tag))
 			}
 			return nil, fmt.Errorf("API error (%d): %s (key: %s)", resp.StatusCode, apiError.Message, apiError.Key)
 		}
@@ -119,5 +144,6 @@ func (c *Client) GetProjectTemplate(ctx context.Context, aiTool string, tag stri
 		return nil, fmt.Errorf("API returned empty download URL")
 	}
 
+	template.FetchedAt = fetchedAt
 	return &template, nil
 }
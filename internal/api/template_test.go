@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/url"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/momorph/cli/internal/version"
+)
+
+func TestTemplatePresignPath(t *testing.T) {
+	wantShell := "sh"
+	if runtime.GOOS == "windows" {
+		wantShell = "ps"
+	}
+
+	tests := []struct {
+		name        string
+		build       string
+		tag         string
+		wantVersion string
+	}{
+		{"release build, no tag", "1.4.2", "", "stable"},
+		{"dev build, no tag", "dev", "", "latest"},
+		{"unset build, no tag", "", "", "latest"},
+		{"tag stable", "dev", "stable", "stable"},
+		{"tag latest", "1.4.2", "latest", "latest"},
+		{"explicit version tag", "1.4.2", "v1.2.0", "v1.2.0"},
+		{"tag needing escaping", "1.4.2", "a&b=c d", "a&b=c d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := version.Version
+			version.Version = tt.build
+			defer func() { version.Version = saved }()
+
+			path := templatePresignPath("copilot", tt.tag)
+
+			base, rawQuery, ok := strings.Cut(path, "?")
+			if !ok || base != "/g/bff/api/project-template/presign" {
+				t.Fatalf("path = %q, want the presign endpoint with a query", path)
+			}
+			query, err := url.ParseQuery(rawQuery)
+			if err != nil {
+				t.Fatalf("query %q does not parse: %v", rawQuery, err)
+			}
+			if len(query) != 3 {
+				t.Errorf("query = %v, want only agent, shell and version", query)
+			}
+			if got := query.Get("agent"); got != "copilot" {
+				t.Errorf("agent = %q, want copilot", got)
+			}
+			if got := query.Get("shell"); got != wantShell {
+				t.Errorf("shell = %q, want %q", got, wantShell)
+			}
+			if got := query["version"]; len(got) != 1 || got[0] != tt.wantVersion {
+				t.Errorf("version = %q, want [%q]", got, tt.wantVersion)
+			}
+		})
+	}
+}
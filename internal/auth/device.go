@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
 	"time"
+
+	"github.com/momorph/cli/internal/utils"
 )
 
 // DeviceCodeResponse represents GitHub's device code response
@@ -28,13 +31,24 @@ type TokenResponse struct {
 	Scope       string `json:"scope"`
 	Error       string `json:"error"`
 	ErrorDesc   string `json:"error_description"`
+	Interval    int    `json:"interval"` // new minimum polling interval on slow_down
 }
 
+// ErrLoginTimeout is returned when the device code expires before the user
+// authorizes the CLI
+var ErrLoginTimeout = errors.New("login timed out, please try again")
+
 const (
 	// GitHub OAuth endpoints
 	deviceCodeURL  = "https://github.com/login/device/code"
 	accessTokenURL = "https://github.com/login/oauth/access_token"
 
+	// defaultDeviceCodeExpiry is used if GitHub does not send expires_in
+	defaultDeviceCodeExpiry = 15 * time.Minute
+
+	// slowDownIncrement is added to the polling interval on each slow_down
+	slowDownIncrement = 5 * time.Second
+
 	// Default GitHub OAuth client ID for device flow (organization app)
 	// Can be overridden by setting MOMORPH_GITHUB_CLIENT_ID environment variable
 	defaultClientID = "Ov23lihLTJKLFI2LJfq1"
@@ -49,12 +63,12 @@ func getClientID() string {
 	return defaultClientID
 }
 
-// RequestDeviceCode requests a device code from GitHub
-func RequestDeviceCode(ctx context.Context) (*DeviceCodeResponse, error) {
+// RequestDeviceCode requests a device code from GitHub for the given OAuth scope
+func RequestDeviceCode(ctx context.Context, scope string) (*DeviceCodeResponse, error) {
 	// Prepare request body
 	reqBody := map[string]string{
 		"client_id": getClientID(),
-		"scope":     "read:user",
+		"scope":     scope,
 	}
 
 	jsonData, err := json.Marshal(reqBody)
@@ -72,7 +86,7 @@ func RequestDeviceCode(ctx context.Context) (*DeviceCodeResponse, error) {
 	req.Header.Set("Accept", "application/json")
 
 	// Send request
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := utils.NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
@@ -94,44 +108,78 @@ func RequestDeviceCode(ctx context.Context) (*DeviceCodeResponse, error) {
 	return &deviceCode, nil
 }
 
-// PollForToken polls GitHub for the access token
-func PollForToken(ctx context.Context, deviceCode string, interval int) (*TokenResponse, error) {
-	ticker := time.NewTicker(time.Duration(interval) * time.Second)
-	defer ticker.Stop()
+// PollForToken polls GitHub for the access token until the user authorizes
+// the device, the device code expires (ErrLoginTimeout) or ctx is done.
+// onWait, if not nil, is called before each wait with the time left.
+func PollForToken(ctx context.Context, deviceCode *DeviceCodeResponse, onWait func(remaining time.Duration)) (*TokenResponse, error) {
+	expiresIn := time.Duration(deviceCode.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = defaultDeviceCodeExpiry
+	}
+	deadline := time.Now().Add(expiresIn)
+
+	interval := time.Duration(deviceCode.Interval) * time.Second
+	if interval <= 0 {
+		interval = slowDownIncrement
+	}
 
 	for {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, ErrLoginTimeout
+		}
+		if onWait != nil {
+			onWait(remaining)
+		}
+
+		wait := interval
+		if wait > remaining {
+			wait = remaining
+		}
+
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-ticker.C:
-			token, err := checkToken(ctx, deviceCode)
-			if err != nil {
-				return nil, err
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, ErrLoginTimeout
 			}
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		if time.Now().After(deadline) {
+			return nil, ErrLoginTimeout
+		}
+
+		token, err := checkToken(ctx, deviceCode.DeviceCode)
+		if err != nil {
+			return nil, err
+		}
 
-			// Check for errors
-			if token.Error != "" {
-				switch token.Error {
-				case "authorization_pending":
-					// Continue polling
-					continue
-				case "slow_down":
-					// Increase interval
-					ticker.Reset(time.Duration(interval+5) * time.Second)
-					continue
-				case "expired_token":
-					return nil, fmt.Errorf("device code expired")
-				case "access_denied":
-					return nil, fmt.Errorf("authorization denied by user")
-				default:
-					return nil, fmt.Errorf("authorization error: %s - %s", token.Error, token.ErrorDesc)
+		// Check for errors
+		if token.Error != "" {
+			switch token.Error {
+			case "authorization_pending":
+				// Continue polling
+				continue
+			case "slow_down":
+				// Back off cumulatively; GitHub may also send the new minimum
+				interval += slowDownIncrement
+				if serverInterval := time.Duration(token.Interval) * time.Second; serverInterval > interval {
+					interval = serverInterval
 				}
+				continue
+			case "expired_token":
+				return nil, ErrLoginTimeout
+			case "access_denied":
+				return nil, fmt.Errorf("authorization denied by user")
+			default:
+				return nil, fmt.Errorf("authorization error: %s - %s", token.Error, token.ErrorDesc)
 			}
+		}
 
-			// Success
-			if token.AccessToken != "" {
-				return token, nil
-			}
+		// Success
+		if token.AccessToken != "" {
+			return token, nil
 		}
 	}
 }
@@ -154,7 +202,7 @@ func checkToken(ctx context.Context, deviceCode string) (*TokenResponse, error)
 	req.Header.Set("Accept", "application/json")
 
 	// Send request
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := utils.NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
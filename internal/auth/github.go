@@ -3,10 +3,12 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"time"
+
+	"github.com/momorph/cli/internal/utils"
 )
 
 // GitHubUser represents a GitHub user
@@ -16,8 +18,14 @@ type GitHubUser struct {
 	AvatarURL string `json:"avatar_url"`
 	Name      string `json:"name"`
 	Email     string `json:"email"`
+	// Scopes granted to the token, from the X-OAuth-Scopes response header
+	Scopes []string `json:"-"`
 }
 
+// ErrInvalidGitHubToken is returned when GitHub rejects the token, e.g.
+// because it was revoked
+var ErrInvalidGitHubToken = errors.New("invalid GitHub token")
+
 // GetAuthenticatedUser retrieves the authenticated user's information from GitHub
 func GetAuthenticatedUser(ctx context.Context, accessToken string) (*GitHubUser, error) {
 	// Create request
@@ -32,7 +40,7 @@ func GetAuthenticatedUser(ctx context.Context, accessToken string) (*GitHubUser,
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 
 	// Send request
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := utils.NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
@@ -41,9 +49,9 @@ func GetAuthenticatedUser(ctx context.Context, accessToken string) (*GitHubUser,
 
 	// Check status code
 	if resp.StatusCode == http.StatusUnauthorized {
-		return nil, fmt.Errorf("invalid GitHub token")
+		return nil, ErrInvalidGitHubToken
 	}
-	
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("GitHub API error (status %d): %s", resp.StatusCode, string(body))
@@ -54,6 +62,7 @@ func GetAuthenticatedUser(ctx context.Context, accessToken string) (*GitHubUser,
 	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
+	user.Scopes = ParseScopes(resp.Header.Get("X-OAuth-Scopes"))
 
 	return &user, nil
 }
@@ -3,14 +3,19 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"time"
 
 	"github.com/momorph/cli/internal/config"
+	"github.com/momorph/cli/internal/utils"
 )
 
+// ErrMoMorphAccessDenied is returned when MoMorph accepts the GitHub token
+// but the user may not use MoMorph
+var ErrMoMorphAccessDenied = errors.New("access denied: you may not have permission to use MoMorph")
+
 // MoMorphUser represents a MoMorph user from the whoami API
 type MoMorphUser struct {
 	ID                string
@@ -67,6 +72,9 @@ func GetMoMorphUser(ctx context.Context, githubToken string) (*MoMorphUser, erro
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
+	if err := cfg.ValidateAPIEndpoint(); err != nil {
+		return nil, err
+	}
 
 	// Build API endpoint
 	endpoint := cfg.GetAPIEndpoint() + "/api/sessions/whoami"
@@ -80,10 +88,9 @@ func GetMoMorphUser(ctx context.Context, githubToken string) (*MoMorphUser, erro
 	// Set headers
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("x-github-token", githubToken)
-	req.Header.Set("User-Agent", "MoMorph-CLI/1.0.0")
 
 	// Send request
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := utils.NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
@@ -96,9 +103,9 @@ func GetMoMorphUser(ctx context.Context, githubToken string) (*MoMorphUser, erro
 
 		switch resp.StatusCode {
 		case http.StatusUnauthorized:
-			return nil, fmt.Errorf("invalid GitHub token")
+			return nil, ErrInvalidGitHubToken
 		case http.StatusForbidden:
-			return nil, fmt.Errorf("access denied: you may not have permission to use MoMorph")
+			return nil, ErrMoMorphAccessDenied
 		case http.StatusTooManyRequests:
 			return nil, fmt.Errorf("rate limit exceeded, please try again later")
 		default:
@@ -154,10 +161,9 @@ func ExchangeGitHubToken(ctx context.Context, githubToken string) (*MoMorphToken
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("x-github-token", githubToken)
-	req.Header.Set("User-Agent", "MoMorph-CLI/1.0.0")
 
 	// Send request
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := utils.NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
@@ -170,9 +176,9 @@ func ExchangeGitHubToken(ctx context.Context, githubToken string) (*MoMorphToken
 
 		switch resp.StatusCode {
 		case http.StatusUnauthorized:
-			return nil, fmt.Errorf("invalid GitHub token")
+			return nil, ErrInvalidGitHubToken
 		case http.StatusForbidden:
-			return nil, fmt.Errorf("access denied: you may not have permission to use MoMorph")
+			return nil, ErrMoMorphAccessDenied
 		case http.StatusTooManyRequests:
 			return nil, fmt.Errorf("rate limit exceeded, please try again later")
 		default:
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"os"
+	"strings"
+
+	"github.com/momorph/cli/internal/config"
+)
+
+// DefaultScope is the GitHub OAuth scope requested when none is configured
+const DefaultScope = "read:user"
+
+// RequestedScope returns the GitHub OAuth scope to request during login
+// Priority: MOMORPH_GITHUB_SCOPE environment variable > config file > DefaultScope
+func RequestedScope() string {
+	if scope := os.Getenv("MOMORPH_GITHUB_SCOPE"); scope != "" {
+		return scope
+	}
+	if cfg, err := config.Load(); err == nil && cfg.GitHubScope != "" {
+		return cfg.GitHubScope
+	}
+	return DefaultScope
+}
+
+// ParseScopes splits a scope string into individual scopes. GitHub returns
+// granted scopes comma-separated, while requested scopes are space-separated,
+// so both separators are accepted.
+func ParseScopes(scope string) []string {
+	fields := strings.FieldsFunc(scope, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	scopes := make([]string, 0, len(fields))
+	for _, field := range fields {
+		scopes = append(scopes, strings.TrimSpace(field))
+	}
+	return scopes
+}
+
+// MissingScopes returns the requested scopes that are not covered by the
+// granted ones. The user may deselect scopes on the authorization page, so
+// GitHub can grant less than was asked for.
+func MissingScopes(requested, granted string) []string {
+	grantedScopes := ParseScopes(granted)
+
+	var missing []string
+	for _, want := range ParseScopes(requested) {
+		covered := false
+		for _, have := range grantedScopes {
+			if scopeCovers(have, want) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			missing = append(missing, want)
+		}
+	}
+	return missing
+}
+
+// scopeCovers reports whether a granted scope includes the wanted one, e.g.
+// "user" covers "read:user" and "repo" covers "repo:status"
+func scopeCovers(have, want string) bool {
+	if have == want {
+		return true
+	}
+	if strings.HasPrefix(want, have+":") {
+		return true
+	}
+	if action, resource, ok := strings.Cut(want, ":"); ok {
+		switch action {
+		case "read":
+			return have == resource || have == "write:"+resource || have == "admin:"+resource
+		case "write":
+			return have == resource || have == "admin:"+resource
+		}
+	}
+	return false
+}
@@ -4,6 +4,25 @@ package auth
 type AuthToken struct {
 	// GitHub OAuth Token (used directly with MoMorph API)
 	GitHubToken string `json:"github_token"`
+	// Scopes granted by GitHub for the token
+	GitHubScopes []string `json:"github_scopes,omitempty"`
+
+	// Cached MoMorph profile, so whoami can answer without a network call
+	Email     string `json:"email,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	TimeZone  string `json:"time_zone,omitempty"`
+}
+
+// SetUser caches the profile fields of a MoMorph user on the token
+func (t *AuthToken) SetUser(user *MoMorphUser) {
+	t.Email = user.Email
+	t.CreatedAt = user.CreatedAt
+	t.TimeZone = user.TimeZone
+}
+
+// HasCachedUser reports whether a MoMorph profile is cached on the token
+func (t *AuthToken) HasCachedUser() bool {
+	return t.Email != ""
 }
 
 // IsValid checks if the GitHub token exists
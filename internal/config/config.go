@@ -1,9 +1,13 @@
 package config
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
 	"os"
-	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -17,22 +21,169 @@ type UserConfig struct {
 	UpdateCheckEnabled bool      `json:"update_check_enabled"`
 	TelemetryEnabled   bool      `json:"telemetry_enabled"`
 	ConfigVersion      string    `json:"config_version"`
+	// GitHubScope is the OAuth scope requested at login (space-separated)
+	GitHubScope string `json:"github_scope,omitempty"`
 	// Basic Auth credentials (not persisted to disk, loaded from env vars only)
 	BasicAuthUsername string `json:"-"`
 	BasicAuthPassword string `json:"-"`
+	// Bearer token for staging gateways (not persisted to disk, loaded from env vars only)
+	StagingBearerToken string `json:"-"`
+}
+
+// AITools lists the AI tools a project can be initialized for
+var AITools = []string{"copilot", "cursor", "claude", "windsurf", "gemini"}
+
+// IsValidAITool reports whether name is one of AITools
+func IsValidAITool(name string) bool {
+	for _, tool := range AITools {
+		if tool == name {
+			return true
+		}
+	}
+	return false
+}
+
+// apiEndpointOverride is set from the --endpoint flag. It takes precedence
+// over MOMORPH_API_ENDPOINT and the config file for the current process.
+var apiEndpointOverride string
+
+// SetAPIEndpointOverride makes every configuration loaded afterwards use
+// endpoint as its API endpoint. An empty value removes the override.
+func SetAPIEndpointOverride(endpoint string) {
+	apiEndpointOverride = strings.TrimRight(endpoint, "/")
+}
+
+// Environments accepted by --env and MOMORPH_ENV
+const (
+	EnvProduction = "production"
+	EnvStaging    = "staging"
+)
+
+// DefaultAPIEndpoint is the production API endpoint
+const DefaultAPIEndpoint = "https://momorph.ai"
+
+// environmentOverride is set from the --env flag. It takes precedence over
+// MOMORPH_ENV and selects the API endpoint for the current process.
+var environmentOverride string
+
+// NormalizeEnvironment maps an environment name or its short form (prod,
+// stg) to EnvProduction or EnvStaging
+func NormalizeEnvironment(name string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case EnvProduction, "prod":
+		return EnvProduction, nil
+	case EnvStaging, "stg":
+		return EnvStaging, nil
+	default:
+		return "", fmt.Errorf("invalid environment %q (must be one of: production, staging)", name)
+	}
+}
+
+// SetEnvironmentOverride selects env (EnvProduction or EnvStaging) for every
+// configuration loaded afterwards. An empty value removes the override.
+func SetEnvironmentOverride(env string) {
+	environmentOverride = env
+}
+
+// Environment returns the selected environment: the --env flag, else
+// MOMORPH_ENV, else "" when none was chosen
+func Environment() string {
+	if environmentOverride != "" {
+		return environmentOverride
+	}
+	env, err := NormalizeEnvironment(os.Getenv("MOMORPH_ENV"))
+	if err != nil {
+		return ""
+	}
+	return env
+}
+
+// EnvironmentEndpoint returns the API endpoint of env: DefaultAPIEndpoint for
+// production and MOMORPH_STAGING_API_ENDPOINT for staging
+func EnvironmentEndpoint(env string) (string, error) {
+	if env == EnvProduction {
+		return DefaultAPIEndpoint, nil
+	}
+	endpoint := os.Getenv("MOMORPH_STAGING_API_ENDPOINT")
+	if endpoint == "" {
+		return "", fmt.Errorf("the staging environment requires MOMORPH_STAGING_API_ENDPOINT (or --endpoint)")
+	}
+	return strings.TrimRight(endpoint, "/"), nil
+}
+
+// ResolvedEnvironment returns the environment API requests go to: the
+// selected one (--env or MOMORPH_ENV), else staging when the API endpoint is
+// MOMORPH_STAGING_API_ENDPOINT, else production
+func ResolvedEnvironment(apiEndpoint string) string {
+	if env := Environment(); env != "" {
+		return env
+	}
+	staging := strings.TrimRight(os.Getenv("MOMORPH_STAGING_API_ENDPOINT"), "/")
+	if staging != "" && strings.TrimRight(apiEndpoint, "/") == staging {
+		return EnvStaging
+	}
+	return EnvProduction
+}
+
+// EnvironmentMCPEndpoint returns the MCP server endpoint of env:
+// DefaultMCPServerEndpoint for production and MOMORPH_STAGING_MCP_ENDPOINT
+// for staging, which is "" when it is not set
+func EnvironmentMCPEndpoint(env string) string {
+	if env == EnvProduction {
+		return DefaultMCPServerEndpoint
+	}
+	return strings.TrimRight(os.Getenv("MOMORPH_STAGING_MCP_ENDPOINT"), "/")
+}
+
+// ValidateEndpoint checks that endpoint is an absolute https:// URL. Since
+// requests carry the GitHub token, plain http:// is only accepted for a
+// server on the loopback interface (localhost, 127.0.0.1, ::1), for local
+// development. Every API endpoint, however it was set, goes through here.
+func ValidateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid endpoint %q: must be an https:// URL", endpoint)
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if isLoopbackHost(u.Hostname()) {
+			return nil
+		}
+		return fmt.Errorf("invalid endpoint %q: must be an https:// URL (http:// is only allowed for localhost)", endpoint)
+	default:
+		return fmt.Errorf("invalid endpoint %q: must be an https:// URL", endpoint)
+	}
+}
+
+// isLoopbackHost reports whether host names the local machine
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
 }
 
 // DefaultConfig returns the default configuration
 func DefaultConfig() *UserConfig {
-	apiEndpoint := "https://momorph.ai"
+	apiEndpoint := DefaultAPIEndpoint
 
 	// Allow direct override via MOMORPH_API_ENDPOINT
 	if endpoint := os.Getenv("MOMORPH_API_ENDPOINT"); endpoint != "" {
 		apiEndpoint = endpoint
 	}
+	if endpoint := overrideEndpoint(); endpoint != "" {
+		apiEndpoint = endpoint
+	}
 
-	// Set MCP server endpoint with environment override support
-	mcpEndpoint := "https://mcp.momorph.ai/mcp"
+	// Set MCP server endpoint to match the API environment, with environment
+	// override support
+	mcpEndpoint := EnvironmentMCPEndpoint(ResolvedEnvironment(apiEndpoint))
 	if endpoint := os.Getenv("MOMORPH_MCP_ENDPOINT"); endpoint != "" {
 		mcpEndpoint = endpoint
 	}
@@ -45,10 +196,11 @@ func DefaultConfig() *UserConfig {
 		LastUpdateCheck:    time.Time{},
 		UpdateCheckEnabled: true,
 		TelemetryEnabled:   false,
-		ConfigVersion:      "1.0",
-		// Load Basic Auth from environment (never saved to disk for security)
-		BasicAuthUsername: os.Getenv("MOMORPH_BASIC_AUTH_USERNAME"),
-		BasicAuthPassword: os.Getenv("MOMORPH_BASIC_AUTH_PASSWORD"),
+		ConfigVersion:      CurrentConfigVersion,
+		// Load staging credentials from environment (never saved to disk for security)
+		BasicAuthUsername:  os.Getenv("MOMORPH_BASIC_AUTH_USERNAME"),
+		BasicAuthPassword:  os.Getenv("MOMORPH_BASIC_AUTH_PASSWORD"),
+		StagingBearerToken: os.Getenv("MOMORPH_STAGING_BEARER"),
 	}
 }
 
@@ -73,9 +225,29 @@ func Load() (*UserConfig, error) {
 		return nil, err
 	}
 
-	// Always load Basic Auth from environment (never persisted to disk)
+	// Upgrade older configs and persist the result, before any environment
+	// or flag overrides are applied so they are not written to disk
+	if migrate(&config) {
+		if err := config.Save(); err != nil {
+			migrationLog = append(migrationLog, fmt.Sprintf("failed to save migrated config: %v", err))
+		}
+	}
+
+	// Always load staging credentials from environment (never persisted to disk)
 	config.BasicAuthUsername = os.Getenv("MOMORPH_BASIC_AUTH_USERNAME")
 	config.BasicAuthPassword = os.Getenv("MOMORPH_BASIC_AUTH_PASSWORD")
+	config.StagingBearerToken = os.Getenv("MOMORPH_STAGING_BEARER")
+
+	// The --endpoint and --env flags win over the config file
+	if endpoint := overrideEndpoint(); endpoint != "" {
+		config.APIEndpoint = endpoint
+	}
+
+	// Outside production, the production MCP endpoint saved by default is
+	// replaced by the one of the environment; a custom endpoint is kept
+	if env := ResolvedEnvironment(config.APIEndpoint); env != EnvProduction && config.MCPServerEndpoint == DefaultMCPServerEndpoint {
+		config.MCPServerEndpoint = EnvironmentMCPEndpoint(env)
+	}
 
 	// Allow MCP endpoint override via environment variable
 	if endpoint := os.Getenv("MOMORPH_MCP_ENDPOINT"); endpoint != "" {
@@ -85,6 +257,20 @@ func Load() (*UserConfig, error) {
 	return &config, nil
 }
 
+// overrideEndpoint returns the API endpoint selected on the command line:
+// --endpoint, else the endpoint of --env, else ""
+func overrideEndpoint() string {
+	if apiEndpointOverride != "" {
+		return apiEndpointOverride
+	}
+	if environmentOverride != "" {
+		if endpoint, err := EnvironmentEndpoint(environmentOverride); err == nil {
+			return endpoint
+		}
+	}
+	return ""
+}
+
 // Save saves the configuration to disk with atomic write
 func (c *UserConfig) Save() error {
 	// Ensure config directory exists
@@ -118,19 +304,13 @@ func (c *UserConfig) Save() error {
 // Validate validates the configuration
 func (c *UserConfig) Validate() error {
 	// Validate API endpoint
-	if c.APIEndpoint == "" {
-		return os.ErrInvalid
+	if err := c.ValidateAPIEndpoint(); err != nil {
+		return err
 	}
 
 	// Validate AI tool if set
 	if c.DefaultAITool != "" {
-		validTools := map[string]bool{
-			"copilot":  true,
-			"cursor":   true,
-			"claude":   true,
-			"windsurf": true,
-		}
-		if !validTools[c.DefaultAITool] {
+		if !IsValidAITool(c.DefaultAITool) {
 			return os.ErrInvalid
 		}
 	}
@@ -149,14 +329,29 @@ func (c *UserConfig) Validate() error {
 	return nil
 }
 
+// ValidateAPIEndpoint checks the API endpoint with ValidateEndpoint, so a
+// typo in the config file or MOMORPH_API_ENDPOINT is reported up front
+// rather than as a failure deep inside a request
+func (c *UserConfig) ValidateAPIEndpoint() error {
+	if c.APIEndpoint == "" {
+		return fmt.Errorf("api_endpoint is not set")
+	}
+	if err := ValidateEndpoint(c.APIEndpoint); err != nil {
+		return fmt.Errorf("api_endpoint: %w", err)
+	}
+	return nil
+}
+
 // GetAPIEndpoint returns the API endpoint with version path
 func (c *UserConfig) GetAPIEndpoint() string {
 	return c.APIEndpoint
 }
 
-// GetTemplateEndpoint returns the full template API endpoint
+// GetTemplateEndpoint returns the full template API endpoint. URLs are
+// joined with "/" explicitly: filepath.Join would use backslashes on Windows
+// and collapse "https://" into "https:/".
 func (c *UserConfig) GetTemplateEndpoint() string {
-	return filepath.Join(c.APIEndpoint, "api", "v1", "get-project-template")
+	return strings.TrimRight(c.APIEndpoint, "/") + "/api/v1/get-project-template"
 }
 
 // HasBasicAuth checks if Basic Auth credentials are configured
@@ -164,8 +359,32 @@ func (c *UserConfig) HasBasicAuth() bool {
 	return c.BasicAuthUsername != "" && c.BasicAuthPassword != ""
 }
 
-// IsStaging checks if the current environment is staging
+// HasStagingBearer checks if a staging gateway bearer token is configured
+func (c *UserConfig) HasStagingBearer() bool {
+	return c.StagingBearerToken != ""
+}
+
+// IsStaging checks if the current environment is staging (--env or MOMORPH_ENV)
 func (c *UserConfig) IsStaging() bool {
-	env := os.Getenv("MOMORPH_ENV")
-	return env == "staging" || env == "stg" || c.HasBasicAuth()
+	return Environment() == EnvStaging
+}
+
+// AuthorizationHeader returns the Authorization header value for API requests.
+// A staging bearer token takes precedence over Basic Auth. Staging
+// credentials are only sent when requests go to staging, as told by
+// ResolvedEnvironment; production needs neither (the x-github-token header
+// is sufficient), so an empty value is returned there, even when staging
+// credentials are set. Staging without any credentials is an error.
+func (c *UserConfig) AuthorizationHeader() (string, error) {
+	if ResolvedEnvironment(c.APIEndpoint) != EnvStaging {
+		return "", nil
+	}
+	if c.HasStagingBearer() {
+		return "Bearer " + c.StagingBearerToken, nil
+	}
+	if c.HasBasicAuth() {
+		credentials := c.BasicAuthUsername + ":" + c.BasicAuthPassword
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials)), nil
+	}
+	return "", fmt.Errorf("staging environment requires MOMORPH_STAGING_BEARER or MOMORPH_BASIC_AUTH_USERNAME and MOMORPH_BASIC_AUTH_PASSWORD")
 }
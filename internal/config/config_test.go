@@ -0,0 +1,83 @@
+package config
+
+import "testing"
+
+func TestValidateEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		wantErr  bool
+	}{
+		{"https://momorph.ai", false},
+		{"https://staging.momorph.ai:8443/base", false},
+		{"http://localhost:3000", false},
+		{"http://LOCALHOST", false},
+		{"http://127.0.0.1:8080", false},
+		{"http://[::1]:8080", false},
+		{"http://momorph.ai", true},
+		{"http://192.168.1.10", true},
+		{"ftp://momorph.ai", true},
+		{"momorph.ai", true},
+		{"https://", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			err := ValidateEndpoint(tt.endpoint)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateEndpoint(%q) = %v, want error: %v", tt.endpoint, err, tt.wantErr)
+			}
+
+			// The config file and environment go through the same policy
+			cfg := &UserConfig{APIEndpoint: tt.endpoint}
+			if apiErr := cfg.ValidateAPIEndpoint(); (apiErr != nil) != tt.wantErr {
+				t.Errorf("ValidateAPIEndpoint(%q) = %v, want error: %v", tt.endpoint, apiErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthorizationHeader(t *testing.T) {
+	const staging = "https://staging.example.com"
+
+	tests := []struct {
+		name     string
+		env      string // MOMORPH_ENV
+		endpoint string
+		bearer   string
+		user     string
+		pass     string
+		want     string
+		wantErr  bool
+	}{
+		{name: "no env, staging creds, production endpoint", endpoint: DefaultAPIEndpoint, bearer: "tok", user: "u", pass: "p", want: ""},
+		{name: "no env, staging creds, other endpoint", endpoint: "https://other.example.com", bearer: "tok", want: ""},
+		{name: "production selected, staging endpoint", env: EnvProduction, endpoint: staging, bearer: "tok", want: ""},
+		{name: "no env, staging endpoint, bearer", endpoint: staging, bearer: "tok", user: "u", pass: "p", want: "Bearer tok"},
+		{name: "no env, staging endpoint, basic auth", endpoint: staging, user: "u", pass: "p", want: "Basic dTpw"},
+		{name: "staging selected, bearer", env: EnvStaging, endpoint: staging, bearer: "tok", want: "Bearer tok"},
+		{name: "staging selected, no creds", env: EnvStaging, endpoint: staging, wantErr: true},
+		{name: "no env, production endpoint, no creds", endpoint: DefaultAPIEndpoint, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MOMORPH_ENV", tt.env)
+			t.Setenv("MOMORPH_STAGING_API_ENDPOINT", staging)
+
+			cfg := &UserConfig{
+				APIEndpoint:        tt.endpoint,
+				StagingBearerToken: tt.bearer,
+				BasicAuthUsername:  tt.user,
+				BasicAuthPassword:  tt.pass,
+			}
+			got, err := cfg.AuthorizationHeader()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AuthorizationHeader() error = %v, want error: %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("AuthorizationHeader() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// CurrentConfigVersion is the config schema version written by this CLI.
+// Configs with an older ConfigVersion are migrated on load.
+const CurrentConfigVersion = "1.1"
+
+// DefaultMCPServerEndpoint is the production MCP server endpoint
+const DefaultMCPServerEndpoint = "https://mcp.momorph.ai/mcp"
+
+// migration upgrades a config to version; apply returns a description of
+// each change it made
+type migration struct {
+	version string
+	apply   func(c *UserConfig) []string
+}
+
+// migrations are applied in order to configs older than their version
+var migrations = []migration{
+	{version: "1.1", apply: migrateTo1_1},
+}
+
+// migrationLog holds the changes made by the last migration, see MigrationLog
+var migrationLog []string
+
+// MigrationLog returns the changes made when the config was last migrated on
+// load. Load runs before the logger is set up, so the caller logs them.
+func MigrationLog() []string {
+	return migrationLog
+}
+
+// migrate upgrades c to CurrentConfigVersion and reports whether anything
+// was changed. Configs written before versioning count as version 1.0.
+func migrate(c *UserConfig) bool {
+	from := c.ConfigVersion
+	if from == "" {
+		from = "1.0"
+	}
+	if compareConfigVersions(from, CurrentConfigVersion) >= 0 {
+		return false
+	}
+
+	var changes []string
+	for _, m := range migrations {
+		if compareConfigVersions(from, m.version) >= 0 {
+			continue
+		}
+		changes = append(changes, m.apply(c)...)
+		c.ConfigVersion = m.version
+	}
+	migrationLog = append([]string{fmt.Sprintf("config version %s -> %s", from, c.ConfigVersion)}, changes...)
+	return true
+}
+
+// migrateTo1_1 moves endpoints off the old momorph.com domain and fills
+// settings that older versions left empty
+func migrateTo1_1(c *UserConfig) []string {
+	var changes []string
+
+	if endpoint, ok := rewriteLegacyHost(c.APIEndpoint); ok {
+		changes = append(changes, fmt.Sprintf("api_endpoint %s -> %s", c.APIEndpoint, endpoint))
+		c.APIEndpoint = endpoint
+	}
+	if endpoint, ok := rewriteLegacyHost(c.MCPServerEndpoint); ok {
+		changes = append(changes, fmt.Sprintf("mcp_server_endpoint %s -> %s", c.MCPServerEndpoint, endpoint))
+		c.MCPServerEndpoint = endpoint
+	}
+
+	if c.APIEndpoint == "" {
+		c.APIEndpoint = DefaultAPIEndpoint
+		changes = append(changes, "api_endpoint set to the default")
+	}
+	if c.MCPServerEndpoint == "" {
+		c.MCPServerEndpoint = DefaultMCPServerEndpoint
+		changes = append(changes, "mcp_server_endpoint set to the default")
+	}
+	if c.LogLevel == "" {
+		c.LogLevel = "info"
+		changes = append(changes, "log_level set to info")
+	}
+
+	return changes
+}
+
+// rewriteLegacyHost maps a URL on momorph.com (or a subdomain) to the same
+// URL on momorph.ai
+func rewriteLegacyHost(endpoint string) (string, bool) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", false
+	}
+	host := u.Hostname()
+	if host != "momorph.com" && !strings.HasSuffix(host, ".momorph.com") {
+		return "", false
+	}
+	newHost := strings.TrimSuffix(host, "momorph.com") + "momorph.ai"
+	if port := u.Port(); port != "" {
+		newHost += ":" + port
+	}
+	u.Host = newHost
+	return u.String(), true
+}
+
+// compareConfigVersions compares dotted numeric versions such as "1.0" and
+// "1.1", returning -1, 0 or 1. Non-numeric parts compare as 0.
+func compareConfigVersions(a, b string) int {
+	pa := strings.Split(a, ".")
+	pb := strings.Split(b, ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var na, nb int
+		if i < len(pa) {
+			na, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			nb, _ = strconv.Atoi(pb[i])
+		}
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -21,6 +21,8 @@ const (
 	ExitAuthError ExitCode = 3
 	// ExitNetworkError indicates a network error
 	ExitNetworkError ExitCode = 4
+	// ExitInterrupted indicates the command was stopped by SIGINT or SIGTERM
+	ExitInterrupted ExitCode = 130
 )
 
 // CLIError represents a CLI error with user-friendly message and exit code
@@ -33,6 +35,9 @@ type CLIError struct {
 	ExitCode ExitCode
 	// StackTrace contains the call stack when debug mode is enabled
 	StackTrace string
+	// Reported is set when the command already showed the error to the
+	// user, so it is not printed again
+	Reported bool
 }
 
 // Error implements the error interface
@@ -54,6 +59,12 @@ func (e *CLIError) WithStackTrace() *CLIError {
 	return e
 }
 
+// AsReported marks the error as already shown to the user
+func (e *CLIError) AsReported() *CLIError {
+	e.Reported = true
+	return e
+}
+
 // NewCLIError creates a new CLIError
 func NewCLIError(technicalErr error, userMsg string, exitCode ExitCode) *CLIError {
 	return &CLIError{
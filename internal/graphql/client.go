@@ -3,11 +3,11 @@ package graphql
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/momorph/cli/internal/auth"
 	"github.com/momorph/cli/internal/config"
@@ -19,6 +19,8 @@ type Client struct {
 	endpoint   string
 	config     *config.UserConfig
 	httpClient *http.Client
+	httpConfig utils.HTTPClientConfig
+	limiter    *utils.RateLimiter
 }
 
 // Request represents a GraphQL request
@@ -46,16 +48,28 @@ func NewClient() (*Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
+	if err := cfg.ValidateAPIEndpoint(); err != nil {
+		return nil, err
+	}
 
 	endpoint := cfg.GetAPIEndpoint() + "/g/bff/v1/graphql"
 
+	httpConfig := utils.DefaultHTTPConfig()
+
 	return &Client{
 		endpoint:   endpoint,
 		config:     cfg,
-		httpClient: utils.NewHTTPClient(),
+		httpClient: utils.NewHTTPClientWithConfig(httpConfig),
+		httpConfig: httpConfig,
 	}, nil
 }
 
+// SetRateLimit limits the client to requestsPerSecond requests on average.
+// A value of 0 or less removes the limit.
+func (c *Client) SetRateLimit(requestsPerSecond float64) {
+	c.limiter = utils.NewRateLimiter(requestsPerSecond, 1)
+}
+
 // Execute executes a GraphQL query or mutation
 func (c *Client) Execute(ctx context.Context, query string, variables map[string]interface{}) (*Response, error) {
 	// Load token
@@ -80,7 +94,11 @@ func (c *Client) Execute(ctx context.Context, query string, variables map[string
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	// Create request
+	// Create request, labelled for timing reports since every operation
+	// goes to the same endpoint
+	if name := operationName(query); name != "" {
+		ctx = utils.WithRequestLabel(ctx, name)
+	}
 	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -93,18 +111,23 @@ func (c *Client) Execute(ctx context.Context, query string, variables map[string
 	req.Header.Set("x-github-token", token.GitHubToken)
 
 	// Set Authorization header for staging environment
-	if c.config.IsStaging() {
-		if c.config.HasBasicAuth() {
-			credentials := c.config.BasicAuthUsername + ":" + c.config.BasicAuthPassword
-			encoded := base64.StdEncoding.EncodeToString([]byte(credentials))
-			req.Header.Set("Authorization", "Basic "+encoded)
-		} else {
-			return nil, fmt.Errorf("staging environment requires MOMORPH_BASIC_AUTH_USERNAME and MOMORPH_BASIC_AUTH_PASSWORD")
-		}
-	}
-
-	// Send request
-	resp, err := c.httpClient.Do(req)
+	authHeader, err := c.config.AuthorizationHeader()
+	if err != nil {
+		return nil, err
+	}
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+
+	// Send request, retrying on 429/5xx and honoring Retry-After. Only
+	// operations that are safe to resend are retried: a mutation that timed
+	// out may still have been applied. Every attempt respects the
+	// client-side rate limit.
+	maxRetries := 0
+	if isRetryable(query) {
+		maxRetries = c.httpConfig.MaxRetries
+	}
+	resp, err := utils.DoWithRetryLimited(ctx, c.httpClient, req, maxRetries, c.httpConfig.RetryBaseDelay, c.limiter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
@@ -118,7 +141,7 @@ func (c *Client) Execute(ctx context.Context, query string, variables map[string
 
 	// Check HTTP status
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("server returned status %d: %s", resp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("server returned an error: %w", &utils.StatusError{StatusCode: resp.StatusCode, Message: string(respBody)})
 	}
 
 	// Parse response
@@ -148,3 +171,38 @@ func (c *Client) ExecuteWithResult(ctx context.Context, query string, variables
 
 	return nil
 }
+
+// operationName returns the name of a GraphQL operation, e.g. "GetFrame" for
+// "query GetFrame($fileKey: String!) {...}", or "" for an anonymous one
+func operationName(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) < 2 || (fields[0] != "query" && fields[0] != "mutation") {
+		return ""
+	}
+	name := fields[1]
+	if i := strings.IndexAny(name, "({"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// idempotentMutations are the mutations that leave the same state however
+// many times they are sent, so they can be retried like queries
+var idempotentMutations = map[string]bool{
+	"UpdateFrameTestcase":           true,
+	"UpsertMultipleDesignItemSpecs": true,
+}
+
+// isRetryable reports whether an operation may be resent after a failure
+// whose outcome is unknown: queries and idempotent mutations
+func isRetryable(query string) bool {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return false
+	}
+	if fields[0] != "mutation" {
+		// "query Name", "query{" or the "{...}" shorthand
+		return strings.HasPrefix(fields[0], "query") || strings.HasPrefix(fields[0], "{")
+	}
+	return idempotentMutations[operationName(query)]
+}
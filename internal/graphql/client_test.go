@@ -0,0 +1,33 @@
+package graphql
+
+import "testing"
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{"GetFrame", queryGetFrame, true},
+		{"ListFrames", queryListFrames, true},
+		{"GetFrameTestCases", queryGetFrameTestCases, true},
+		{"ListDesignItemsByNodeLinkIds", queryListDesignItemsByNodeLinkIds, true},
+		{"GetMorpheusUserByEmail", queryGetMorpheusUserByEmail, true},
+		{"ListFramesByFrameLinkIds", queryListFramesByFrameLinkIds, true},
+		{"UpdateFrameTestcase", mutationUpdateFrameTestcase, true},
+		{"UpsertMultipleDesignItemSpecs", mutationUpsertDesignItemSpecs, true},
+		{"InsertFrameTestcase", mutationInsertFrameTestcase, false},
+		{"InsertDesignItemRevs", mutationInsertDesignItemRevs, false},
+		{"anonymous query", "{ frames { id } }", true},
+		{"anonymous mutation", "mutation { delete_frames { affected_rows } }", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.query); got != tt.want {
+				t.Errorf("isRetryable(%s) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
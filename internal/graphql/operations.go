@@ -67,6 +67,22 @@ query GetFrame($fileKey: String!, $frameLinkId: String!) {
     status
   }
 }
+`
+
+	// ListFrames query - all frames of a file, ordered by name
+	queryListFrames = `
+query ListFrames($fileKey: String!) {
+  frames(
+    where: {file: {file_key: {_eq: $fileKey}}},
+    order_by: {name: asc}
+  ) {
+    id
+    frame_link_id
+    file_id
+    name
+    status
+  }
+}
 `
 
 	// GetFrameTestCases query - uses Hasura standard query with where filter
@@ -140,7 +156,9 @@ query ListFramesByFrameLinkIds($fileKey: String!, $frameLinkIds: [String!]!) {
   ) {
     id
     frame_link_id
+    file_id
     name
+    status
   }
 }
 `
@@ -320,21 +338,25 @@ func (c *Client) UpdateFrameTestcase(ctx context.Context, id int, content interf
 
 // ListDesignItemsByNodeLinkIds fetches design items by node link IDs
 func (c *Client) ListDesignItemsByNodeLinkIds(ctx context.Context, fileKey, frameID string, nodeLinkIds []string) ([]DesignItem, error) {
-	variables := map[string]interface{}{
-		"fileKey":     fileKey,
-		"frameLinkId": frameID,
-		"nodeLinkIds": nodeLinkIds,
-	}
+	var items []DesignItem
+	for _, batch := range batchIDs(nodeLinkIds, maxIDsPerQuery) {
+		variables := map[string]interface{}{
+			"fileKey":     fileKey,
+			"frameLinkId": frameID,
+			"nodeLinkIds": batch,
+		}
 
-	var result struct {
-		DesignItems []DesignItem `json:"design_items"`
-	}
+		var result struct {
+			DesignItems []DesignItem `json:"design_items"`
+		}
 
-	if err := c.ExecuteWithResult(ctx, queryListDesignItemsByNodeLinkIds, variables, &result); err != nil {
-		return nil, err
+		if err := c.ExecuteWithResult(ctx, queryListDesignItemsByNodeLinkIds, variables, &result); err != nil {
+			return nil, err
+		}
+		items = append(items, result.DesignItems...)
 	}
 
-	return result.DesignItems, nil
+	return items, nil
 }
 
 // UpsertDesignItemSpecs upserts multiple design item specs
@@ -396,27 +418,59 @@ func (c *Client) InsertDesignItemRevs(ctx context.Context, revs []map[string]int
 	return result.InsertDesignItemsRevs.AffectedRows, nil
 }
 
-// FrameBasic represents basic frame info for linked frame validation
-type FrameBasic struct {
-	ID          int    `json:"id"`
-	FrameLinkID string `json:"frame_link_id"`
-	Name        string `json:"name"`
-}
-
-// ListFramesByFrameLinkIds fetches frames by their frame link IDs
-func (c *Client) ListFramesByFrameLinkIds(ctx context.Context, fileKey string, frameLinkIds []string) ([]FrameBasic, error) {
+// ListFrames fetches all frames of a file
+func (c *Client) ListFrames(ctx context.Context, fileKey string) ([]Frame, error) {
 	variables := map[string]interface{}{
-		"fileKey":      fileKey,
-		"frameLinkIds": frameLinkIds,
+		"fileKey": fileKey,
 	}
 
 	var result struct {
-		Frames []FrameBasic `json:"frames"`
+		Frames []Frame `json:"frames"`
 	}
 
-	if err := c.ExecuteWithResult(ctx, queryListFramesByFrameLinkIds, variables, &result); err != nil {
+	if err := c.ExecuteWithResult(ctx, queryListFrames, variables, &result); err != nil {
 		return nil, err
 	}
 
 	return result.Frames, nil
 }
+
+// ListFramesByFrameLinkIds fetches the frames of a file with the given frame
+// link IDs, for linked frame validation. Large ID sets are queried in batches.
+func (c *Client) ListFramesByFrameLinkIds(ctx context.Context, fileKey string, frameLinkIds []string) ([]Frame, error) {
+	var frames []Frame
+	for _, batch := range batchIDs(frameLinkIds, maxIDsPerQuery) {
+		variables := map[string]interface{}{
+			"fileKey":      fileKey,
+			"frameLinkIds": batch,
+		}
+
+		var result struct {
+			Frames []Frame `json:"frames"`
+		}
+
+		if err := c.ExecuteWithResult(ctx, queryListFramesByFrameLinkIds, variables, &result); err != nil {
+			return nil, err
+		}
+		frames = append(frames, result.Frames...)
+	}
+
+	return frames, nil
+}
+
+// maxIDsPerQuery caps the IDs sent in one _in filter, keeping request bodies
+// and query plans small for files with many items
+const maxIDsPerQuery = 500
+
+// batchIDs splits ids into consecutive batches of at most size
+func batchIDs(ids []string, size int) [][]string {
+	var batches [][]string
+	for len(ids) > size {
+		batches = append(batches, ids[:size])
+		ids = ids[size:]
+	}
+	if len(ids) > 0 {
+		batches = append(batches, ids)
+	}
+	return batches
+}
@@ -0,0 +1,78 @@
+// Package i18n provides translated user-facing messages for the CLI.
+// Messages are looked up by key in the active language catalog and fall back
+// to English when a key or language is missing.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultLanguage is used when no supported language is detected
+const DefaultLanguage = "en"
+
+// catalogs maps a language code to its message catalog
+var catalogs = map[string]map[string]string{
+	"en": messagesEN,
+	"vi": messagesVI,
+	"ja": messagesJA,
+}
+
+var current = DefaultLanguage
+
+// SetLanguage sets the active language. Locale strings such as "ja_JP.UTF-8"
+// are accepted; unsupported languages fall back to English.
+func SetLanguage(lang string) {
+	current = Normalize(lang)
+}
+
+// Language returns the active language code
+func Language() string {
+	return current
+}
+
+// SupportedLanguages returns the language codes that have a catalog
+func SupportedLanguages() []string {
+	return []string{"en", "vi", "ja"}
+}
+
+// Normalize converts a locale string (e.g. "vi_VN.UTF-8") to a supported
+// language code, returning DefaultLanguage if it is not supported
+func Normalize(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "_-.@"); i != -1 {
+		lang = lang[:i]
+	}
+	if _, ok := catalogs[lang]; ok {
+		return lang
+	}
+	return DefaultLanguage
+}
+
+// DetectLanguage determines the language from the environment
+// Priority: MOMORPH_LANG > LC_ALL > LC_MESSAGES > LANG
+func DetectLanguage() string {
+	for _, env := range []string{"MOMORPH_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(env); value != "" {
+			return Normalize(value)
+		}
+	}
+	return DefaultLanguage
+}
+
+// T returns the message for key in the active language, formatted with args
+func T(key string, args ...interface{}) string {
+	msg, ok := catalogs[current][key]
+	if !ok {
+		msg, ok = messagesEN[key]
+		if !ok {
+			msg = key
+		}
+	}
+
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
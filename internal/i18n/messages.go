@@ -0,0 +1,283 @@
+package i18n
+
+// messagesEN is the English catalog and the fallback for every other language
+var messagesEN = map[string]string{
+	// Authentication
+	"auth.not_authenticated":   "✗ Not authenticated",
+	"auth.login_before_init":   "Run 'momorph login' to authenticate before initializing projects",
+	"auth.login_before_upload": "Run 'momorph login' to authenticate before uploading",
+
+	// Login
+	"login.already_authenticated": "✓ Already authenticated. Use 'momorph logout' to sign out.",
+	"login.cancelled":             "✗ Login cancelled by user",
+	"login.requesting_code":       "🔑 Requesting device code from GitHub",
+	"login.step_open_browser":     "1. Press Enter to open your browser: %s",
+	"login.step_enter_code":       "2. Enter this code: %s",
+	"login.press_enter":           "Press Enter to continue...",
+	"login.step_visit":            "1. Open this URL in a browser on any device: %s",
+	"login.headless_detected":     "ℹ No local browser detected (SSH session or no display); use the link below.",
+	"login.opening_browser":       "🌐 Opening browser...",
+	"login.browser_failed":        "⚠  Could not open browser automatically. Please visit: %s",
+	"login.waiting_remaining":     "⏳ Waiting for authorization... (%s left)",
+	"login.scope_missing":         "⚠ GitHub did not grant all requested permissions (missing: %s); some features may not work.",
+	"login.fetching_user":         "👤 Fetching user information...",
+	"login.saving":                "💾 Saving credentials...",
+	"login.success":               "✓ Successfully authenticated!",
+	"login.logged_in_as":          "  Logged in as: %s",
+
+	// Init
+	"init.cancelled":            "Initialization cancelled",
+	"init.starting":             "🚀 Initializing MoMorph project with %s",
+	"init.fetching":             "📋 Fetching template...",
+	"init.local_template":       "📋 Using local template %s",
+	"init.custom_template":      "📋 Using template from %s",
+	"init.dry_run_header":       "[DRY RUN] %d file(s) would be written to %s:",
+	"init.dry_run_counts":       "%d new, %d merged, %d overwritten",
+	"init.dry_run_done":         "Nothing was written. Run without --dry-run to initialize.",
+	"init.downloading":          "📥 Downloading...",
+	"init.url_expired":          "⚠ The download link expired, fetching a new one...",
+	"init.cached_template":      "⚠ Could not fetch the template (%v), using the cached copy",
+	"init.extracting":           "📦 Extracting...",
+	"init.configuring":          "🔧 Configuring...",
+	"init.installing_extension": "📦 Installing VS Code extension...",
+	"init.success":              "✓ Project initialized successfully!",
+	"init.project":              "  Project: %s",
+	"init.directory":            "  Directory: %s",
+	"init.ai_tool":              "  AI tool: %s",
+	"init.next_steps":           "-> Next steps:",
+	"init.enjoy":                "  Enjoy building with MoMorph! 🚀",
+
+	// Upload
+	"upload.cancelled":           "✗ Upload cancelled",
+	"upload.no_files":            "No CSV files found to upload",
+	"upload.path_hint":           "Make sure files are in the correct path format:",
+	"upload.no_valid_files":      "No valid files to upload",
+	"upload.uploading_specs":     "Uploading %d spec file(s)...",
+	"upload.uploading_testcases": "Uploading %d test case file(s)...",
+	"upload.summary":             "Summary",
+	"upload.summary_total":       "  Total files:  %d",
+	"upload.summary_success":     "  Success:      %d",
+	"upload.summary_failed":      "  Failed:       %d",
+	"upload.summary_skipped":     "  Skipped:      %d",
+	"upload.summary_unchanged":   "  Unchanged rows not re-sent: %d",
+	"upload.all_succeeded":       "✓ Successfully uploaded %d file(s)",
+	"upload.all_failed":          "✗ All uploads failed or were skipped",
+	"upload.partial":             "⚠ Uploaded %d file(s), %d failed, %d skipped",
+	"upload.all_specs":           "Specs:      %d succeeded, %d failed, %d skipped",
+	"upload.all_testcases":       "Test cases: %d succeeded, %d failed, %d skipped",
+
+	// Update
+	"update.current_version":       "Current version: %s",
+	"update.checking":              "🔍 Checking for updates...",
+	"update.looking_up":            "🔍 Looking up version %s...",
+	"update.check_failed":          "✗ Failed to check for updates",
+	"update.check_connection":      "  Please check your internet connection and try again.",
+	"update.no_releases":           "✓ No update available: no releases have been published yet.",
+	"update.already_latest":        "✓ Already on the latest version!",
+	"update.already_on":            "✓ Already on version %s!",
+	"update.available":             "⚡ Update available:",
+	"update.downgrade":             "⬇ Downgrade:",
+	"update.release_notes":         "   Release notes: %s",
+	"update.package_manager":       "MoMorph CLI was installed with %s. To update, run:",
+	"update.package_manager_force": "Use --force to replace the binary in place anyway.",
+	"update.run_to_install":        "Run 'momorph update' (without --check) to install the update.",
+	"update.not_writable":          "✗ Cannot update this installation",
+	"update.no_platform_asset":     "✗ No release available for your platform",
+	"update.download_manually":     "  Please download manually from: %s",
+	"update.unverified":            "⚠ The download will not be verified: %v",
+	"update.unverified_force":      "Use --force to install it without verification.",
+	"update.cancelled":             "Update cancelled",
+	"update.downloading":           "📥 Downloading %s...",
+	"update.failed":                "✗ Failed to update",
+	"update.retry_or_download":     "  Please try again or download manually from: %s",
+	"update.success":               "✓ Updated successfully!",
+	"update.binary":                "  Binary: %s",
+}
+
+// messagesVI is the Vietnamese catalog
+var messagesVI = map[string]string{
+	// Authentication
+	"auth.not_authenticated":   "✗ Chưa đăng nhập",
+	"auth.login_before_init":   "Chạy 'momorph login' để đăng nhập trước khi khởi tạo dự án",
+	"auth.login_before_upload": "Chạy 'momorph login' để đăng nhập trước khi tải lên",
+
+	// Login
+	"login.already_authenticated": "✓ Đã đăng nhập. Dùng 'momorph logout' để đăng xuất.",
+	"login.cancelled":             "✗ Người dùng đã hủy đăng nhập",
+	"login.requesting_code":       "🔑 Đang yêu cầu mã thiết bị từ GitHub",
+	"login.step_open_browser":     "1. Nhấn Enter để mở trình duyệt: %s",
+	"login.step_enter_code":       "2. Nhập mã này: %s",
+	"login.press_enter":           "Nhấn Enter để tiếp tục...",
+	"login.step_visit":            "1. Mở URL này trên trình duyệt của bất kỳ thiết bị nào: %s",
+	"login.headless_detected":     "ℹ Không phát hiện trình duyệt cục bộ (phiên SSH hoặc không có màn hình); hãy dùng liên kết bên dưới.",
+	"login.opening_browser":       "🌐 Đang mở trình duyệt...",
+	"login.browser_failed":        "⚠  Không thể tự động mở trình duyệt. Vui lòng truy cập: %s",
+	"login.waiting_remaining":     "⏳ Đang chờ xác thực... (còn %s)",
+	"login.scope_missing":         "⚠ GitHub không cấp đủ các quyền được yêu cầu (thiếu: %s); một số tính năng có thể không hoạt động.",
+	"login.fetching_user":         "👤 Đang lấy thông tin người dùng...",
+	"login.saving":                "💾 Đang lưu thông tin đăng nhập...",
+	"login.success":               "✓ Đăng nhập thành công!",
+	"login.logged_in_as":          "  Đăng nhập với: %s",
+
+	// Init
+	"init.cancelled":            "Đã hủy khởi tạo",
+	"init.starting":             "🚀 Đang khởi tạo dự án MoMorph với %s",
+	"init.fetching":             "📋 Đang lấy template...",
+	"init.local_template":       "📋 Đang dùng template cục bộ %s",
+	"init.custom_template":      "📋 Đang dùng template từ %s",
+	"init.dry_run_header":       "[DRY RUN] %d tệp sẽ được ghi vào %s:",
+	"init.dry_run_counts":       "%d mới, %d gộp, %d ghi đè",
+	"init.dry_run_done":         "Chưa ghi gì cả. Chạy lại không có --dry-run để khởi tạo.",
+	"init.downloading":          "📥 Đang tải xuống...",
+	"init.url_expired":          "⚠ Liên kết tải xuống đã hết hạn, đang lấy liên kết mới...",
+	"init.cached_template":      "⚠ Không thể lấy template (%v), đang dùng bản đã lưu trong cache",
+	"init.extracting":           "📦 Đang giải nén...",
+	"init.configuring":          "🔧 Đang cấu hình...",
+	"init.installing_extension": "📦 Đang cài đặt tiện ích VS Code...",
+	"init.success":              "✓ Khởi tạo dự án thành công!",
+	"init.project":              "  Dự án: %s",
+	"init.directory":            "  Thư mục: %s",
+	"init.ai_tool":              "  Công cụ AI: %s",
+	"init.next_steps":           "-> Bước tiếp theo:",
+	"init.enjoy":                "  Chúc bạn xây dựng vui vẻ với MoMorph! 🚀",
+
+	// Upload
+	"upload.cancelled":           "✗ Đã hủy tải lên",
+	"upload.no_files":            "Không tìm thấy file CSV nào để tải lên",
+	"upload.path_hint":           "Hãy đảm bảo file có đường dẫn đúng định dạng:",
+	"upload.no_valid_files":      "Không có file hợp lệ để tải lên",
+	"upload.uploading_specs":     "Đang tải lên %d file spec...",
+	"upload.uploading_testcases": "Đang tải lên %d file test case...",
+	"upload.summary":             "Tổng kết",
+	"upload.summary_total":       "  Tổng số file: %d",
+	"upload.summary_success":     "  Thành công:   %d",
+	"upload.summary_failed":      "  Thất bại:     %d",
+	"upload.summary_skipped":     "  Bỏ qua:       %d",
+	"upload.summary_unchanged":   "  Dòng không đổi, không gửi lại: %d",
+	"upload.all_succeeded":       "✓ Đã tải lên thành công %d file",
+	"upload.all_failed":          "✗ Tất cả file đều thất bại hoặc bị bỏ qua",
+	"upload.partial":             "⚠ Đã tải lên %d file, %d thất bại, %d bỏ qua",
+	"upload.all_specs":           "Spec:      %d thành công, %d thất bại, %d bỏ qua",
+	"upload.all_testcases":       "Test case: %d thành công, %d thất bại, %d bỏ qua",
+
+	// Update
+	"update.current_version":       "Phiên bản hiện tại: %s",
+	"update.checking":              "🔍 Đang kiểm tra bản cập nhật...",
+	"update.looking_up":            "🔍 Đang tìm phiên bản %s...",
+	"update.check_failed":          "✗ Không thể kiểm tra bản cập nhật",
+	"update.check_connection":      "  Vui lòng kiểm tra kết nối internet và thử lại.",
+	"update.no_releases":           "✓ Không có bản cập nhật: chưa có bản phát hành nào.",
+	"update.already_latest":        "✓ Bạn đang dùng phiên bản mới nhất!",
+	"update.already_on":            "✓ Bạn đang dùng phiên bản %s!",
+	"update.available":             "⚡ Có bản cập nhật:",
+	"update.downgrade":             "⬇ Hạ phiên bản:",
+	"update.release_notes":         "   Ghi chú phát hành: %s",
+	"update.package_manager":       "MoMorph CLI được cài bằng %s. Để cập nhật, hãy chạy:",
+	"update.package_manager_force": "Dùng --force nếu vẫn muốn thay thế trực tiếp file chạy.",
+	"update.run_to_install":        "Chạy 'momorph update' (không có --check) để cài bản cập nhật.",
+	"update.not_writable":          "✗ Không thể cập nhật bản cài đặt này",
+	"update.no_platform_asset":     "✗ Không có bản phát hành cho nền tảng của bạn",
+	"update.download_manually":     "  Vui lòng tải thủ công tại: %s",
+	"update.unverified":            "⚠ Bản tải xuống sẽ không được kiểm tra: %v",
+	"update.unverified_force":      "Dùng --force nếu vẫn muốn cài đặt mà không kiểm tra.",
+	"update.cancelled":             "Đã hủy cập nhật",
+	"update.downloading":           "📥 Đang tải xuống %s...",
+	"update.failed":                "✗ Cập nhật thất bại",
+	"update.retry_or_download":     "  Vui lòng thử lại hoặc tải thủ công tại: %s",
+	"update.success":               "✓ Cập nhật thành công!",
+	"update.binary":                "  File chạy: %s",
+}
+
+// messagesJA is the Japanese catalog
+var messagesJA = map[string]string{
+	// Authentication
+	"auth.not_authenticated":   "✗ 認証されていません",
+	"auth.login_before_init":   "プロジェクトを初期化する前に 'momorph login' で認証してください",
+	"auth.login_before_upload": "アップロードする前に 'momorph login' で認証してください",
+
+	// Login
+	"login.already_authenticated": "✓ 認証済みです。サインアウトするには 'momorph logout' を使用してください。",
+	"login.cancelled":             "✗ ログインがキャンセルされました",
+	"login.requesting_code":       "🔑 GitHub にデバイスコードを要求しています",
+	"login.step_open_browser":     "1. Enter キーを押してブラウザを開きます: %s",
+	"login.step_enter_code":       "2. 次のコードを入力してください: %s",
+	"login.press_enter":           "Enter キーを押して続行...",
+	"login.step_visit":            "1. 任意のデバイスのブラウザで次の URL を開いてください: %s",
+	"login.headless_detected":     "ℹ ローカルのブラウザが見つかりません (SSH セッションまたはディスプレイなし)。下のリンクを使用してください。",
+	"login.opening_browser":       "🌐 ブラウザを開いています...",
+	"login.browser_failed":        "⚠  ブラウザを自動で開けませんでした。次の URL にアクセスしてください: %s",
+	"login.waiting_remaining":     "⏳ 認可を待っています... (残り %s)",
+	"login.scope_missing":         "⚠ GitHub が要求したすべての権限を付与しませんでした (不足: %s)。一部の機能が動作しない可能性があります。",
+	"login.fetching_user":         "👤 ユーザー情報を取得しています...",
+	"login.saving":                "💾 認証情報を保存しています...",
+	"login.success":               "✓ 認証に成功しました!",
+	"login.logged_in_as":          "  ログインユーザー: %s",
+
+	// Init
+	"init.cancelled":            "初期化がキャンセルされました",
+	"init.starting":             "🚀 %s で MoMorph プロジェクトを初期化しています",
+	"init.fetching":             "📋 テンプレートを取得しています...",
+	"init.local_template":       "📋 ローカルテンプレート %s を使用します",
+	"init.custom_template":      "📋 %s のテンプレートを使用します",
+	"init.dry_run_header":       "[DRY RUN] %d 個のファイルが %s に書き込まれます:",
+	"init.dry_run_counts":       "新規 %d、マージ %d、上書き %d",
+	"init.dry_run_done":         "何も書き込まれていません。初期化するには --dry-run なしで実行してください。",
+	"init.downloading":          "📥 ダウンロードしています...",
+	"init.url_expired":          "⚠ ダウンロードリンクの有効期限が切れたため、新しいリンクを取得しています...",
+	"init.cached_template":      "⚠ テンプレートを取得できなかったため (%v)、キャッシュのコピーを使用します",
+	"init.extracting":           "📦 展開しています...",
+	"init.configuring":          "🔧 設定しています...",
+	"init.installing_extension": "📦 VS Code 拡張機能をインストールしています...",
+	"init.success":              "✓ プロジェクトの初期化が完了しました!",
+	"init.project":              "  プロジェクト: %s",
+	"init.directory":            "  ディレクトリ: %s",
+	"init.ai_tool":              "  AI ツール: %s",
+	"init.next_steps":           "-> 次のステップ:",
+	"init.enjoy":                "  MoMorph での開発をお楽しみください! 🚀",
+
+	// Upload
+	"upload.cancelled":           "✗ アップロードがキャンセルされました",
+	"upload.no_files":            "アップロードする CSV ファイルが見つかりません",
+	"upload.path_hint":           "ファイルが正しいパス形式であることを確認してください:",
+	"upload.no_valid_files":      "アップロード可能なファイルがありません",
+	"upload.uploading_specs":     "%d 件のスペックファイルをアップロードしています...",
+	"upload.uploading_testcases": "%d 件のテストケースファイルをアップロードしています...",
+	"upload.summary":             "サマリー",
+	"upload.summary_total":       "  ファイル総数: %d",
+	"upload.summary_success":     "  成功:         %d",
+	"upload.summary_failed":      "  失敗:         %d",
+	"upload.summary_skipped":     "  スキップ:     %d",
+	"upload.summary_unchanged":   "  変更なしで送信しなかった行: %d",
+	"upload.all_succeeded":       "✓ %d 件のファイルをアップロードしました",
+	"upload.all_failed":          "✗ すべてのアップロードが失敗またはスキップされました",
+	"upload.partial":             "⚠ %d 件アップロード、%d 件失敗、%d 件スキップ",
+	"upload.all_specs":           "スペック:     %d 件成功、%d 件失敗、%d 件スキップ",
+	"upload.all_testcases":       "テストケース: %d 件成功、%d 件失敗、%d 件スキップ",
+
+	// Update
+	"update.current_version":       "現在のバージョン: %s",
+	"update.checking":              "🔍 アップデートを確認しています...",
+	"update.looking_up":            "🔍 バージョン %s を検索しています...",
+	"update.check_failed":          "✗ アップデートを確認できませんでした",
+	"update.check_connection":      "  インターネット接続を確認して、もう一度お試しください。",
+	"update.no_releases":           "✓ アップデートはありません: まだリリースが公開されていません。",
+	"update.already_latest":        "✓ 最新バージョンを使用しています!",
+	"update.already_on":            "✓ すでにバージョン %s です!",
+	"update.available":             "⚡ アップデートがあります:",
+	"update.downgrade":             "⬇ ダウングレード:",
+	"update.release_notes":         "   リリースノート: %s",
+	"update.package_manager":       "MoMorph CLI は %s でインストールされています。アップデートするには次を実行してください:",
+	"update.package_manager_force": "それでもバイナリを直接置き換える場合は --force を使用してください。",
+	"update.run_to_install":        "アップデートをインストールするには 'momorph update' (--check なし) を実行してください。",
+	"update.not_writable":          "✗ このインストールはアップデートできません",
+	"update.no_platform_asset":     "✗ お使いのプラットフォーム向けのリリースがありません",
+	"update.download_manually":     "  次の URL から手動でダウンロードしてください: %s",
+	"update.unverified":            "⚠ ダウンロードは検証されません: %v",
+	"update.unverified_force":      "検証せずにインストールする場合は --force を使用してください。",
+	"update.cancelled":             "アップデートをキャンセルしました",
+	"update.downloading":           "📥 %s をダウンロードしています...",
+	"update.failed":                "✗ アップデートに失敗しました",
+	"update.retry_or_download":     "  もう一度お試しいただくか、次の URL から手動でダウンロードしてください: %s",
+	"update.success":               "✓ アップデートしました!",
+	"update.binary":                "  バイナリ: %s",
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/momorph/cli/internal/config"
@@ -16,16 +17,33 @@ var (
 	Log zerolog.Logger
 )
 
+// Log output formats for stderr
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+)
+
+// Options configures the logger
+type Options struct {
+	Debug  bool   // force debug level and log to stderr
+	Level  string // debug, info, warn or error; empty means info
+	Format string // stderr format (console or json); empty logs to stderr only in debug mode
+}
+
 // Init initializes the logger with the specified configuration
-func Init(debug bool) error {
+func Init(opts Options) error {
+	if opts.Format != "" && opts.Format != FormatConsole && opts.Format != FormatJSON {
+		return fmt.Errorf("invalid log format %q (must be console or json)", opts.Format)
+	}
+
 	// Ensure logs directory exists
 	if err := config.EnsureLogsDir(); err != nil {
 		return fmt.Errorf("failed to create logs directory: %w", err)
 	}
 
-	// Set log level
-	logLevel := zerolog.InfoLevel
-	if debug {
+	// Set log level: --debug wins over the configured level
+	logLevel, levelOK := parseLevel(opts.Level)
+	if opts.Debug {
 		logLevel = zerolog.DebugLevel
 	}
 	zerolog.SetGlobalLevel(logLevel)
@@ -36,12 +54,15 @@ func Init(debug bool) error {
 		return fmt.Errorf("failed to create log file: %w", err)
 	}
 
-	// Create multi-writer (file + console for debug mode)
+	// Create multi-writer (file + stderr in debug mode or when a format is requested)
 	var writers []io.Writer
 	writers = append(writers, logFile)
 
-	if debug {
-		// Add console output for debug mode with pretty formatting
+	if opts.Format == FormatJSON {
+		// Raw JSON lines for log aggregators
+		writers = append(writers, os.Stderr)
+	} else if opts.Debug || opts.Format == FormatConsole {
+		// Pretty formatting for humans
 		consoleWriter := zerolog.ConsoleWriter{
 			Out:        os.Stderr,
 			TimeFormat: time.RFC3339,
@@ -57,10 +78,37 @@ func Init(debug bool) error {
 		Str("app", "momorph-cli").
 		Logger()
 
+	if !levelOK {
+		msg := fmt.Sprintf("Unknown log level %q in config, using info (valid: debug, info, warn, error)", opts.Level)
+		Log.Warn().Msg(msg)
+		// Shown on stderr as well, unless the log already went there
+		if len(writers) == 1 {
+			fmt.Fprintf(os.Stderr, "⚠ %s\n", msg)
+		}
+	}
+
 	Log.Debug().Msg("Logger initialized")
 	return nil
 }
 
+// parseLevel converts a config log level (debug, info, warn, error) to a
+// zerolog level. Empty means info; unknown values fall back to info and
+// report false.
+func parseLevel(level string) (zerolog.Level, bool) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return zerolog.DebugLevel, true
+	case "info", "":
+		return zerolog.InfoLevel, true
+	case "warn", "warning":
+		return zerolog.WarnLevel, true
+	case "error":
+		return zerolog.ErrorLevel, true
+	default:
+		return zerolog.InfoLevel, false
+	}
+}
+
 // getLogFile returns the log file for the current date
 func getLogFile() (*os.File, error) {
 	logsDir := config.GetLogsDir()
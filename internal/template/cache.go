@@ -29,6 +29,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/momorph/cli/internal/config"
@@ -75,13 +76,11 @@ func NewCache() (*Cache, error) {
 		cacheDir: cacheDir,
 	}
 
-	// Load existing index
+	// Load existing index, rebuilding it from the cached files if it is
+	// missing or was left corrupt by an interrupted write
 	if err := cache.loadIndex(); err != nil {
-		logger.Debug("No existing cache index, creating new one: %v", err)
-		cache.index = &CacheIndex{
-			Version: "1.0",
-			Entries: make(map[string]CacheEntry),
-		}
+		logger.Debug("No usable cache index, rebuilding it: %v", err)
+		cache.rebuildIndex()
 	}
 
 	return cache, nil
@@ -114,14 +113,108 @@ func (c *Cache) saveIndex() error {
 		return fmt.Errorf("failed to marshal cache index: %w", err)
 	}
 
+	// Write to a temporary file and rename it into place, so a crash never
+	// leaves a partially written index
 	indexPath := filepath.Join(c.cacheDir, "index.json")
-	if err := os.WriteFile(indexPath, data, 0600); err != nil {
+	tempFile := indexPath + ".tmp"
+	if err := os.WriteFile(tempFile, data, 0600); err != nil {
+		return fmt.Errorf("failed to write cache index: %w", err)
+	}
+	if err := os.Rename(tempFile, indexPath); err != nil {
+		os.Remove(tempFile)
 		return fmt.Errorf("failed to write cache index: %w", err)
 	}
 
 	return nil
 }
 
+// rebuildIndex replaces the index with one recovered from the template files
+// in the cache directory, named {aiTool}-{version}-{checksum[:8]}.zip. Files
+// whose contents no longer match the checksum in their name are removed.
+// When a tool has several files, the most recently written one wins.
+func (c *Cache) rebuildIndex() {
+	c.index = &CacheIndex{
+		Version: "1.0",
+		Entries: make(map[string]CacheEntry),
+	}
+
+	files, err := filepath.Glob(filepath.Join(c.cacheDir, "*.zip"))
+	if err != nil || len(files) == 0 {
+		return
+	}
+
+	for _, path := range files {
+		aiTool, version, shortSum, ok := parseCacheFileName(filepath.Base(path))
+		if !ok {
+			logger.Debug("Ignoring unrecognized file in template cache: %s", path)
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		checksum, err := fileChecksum(path)
+		if err != nil || checksum[:8] != shortSum {
+			logger.Debug("Removing corrupt cached template: %s", path)
+			os.Remove(path)
+			continue
+		}
+
+		if existing, ok := c.index.Entries[aiTool]; ok && !info.ModTime().After(existing.CachedAt) {
+			continue
+		}
+		c.index.Entries[aiTool] = CacheEntry{
+			AITool:   aiTool,
+			Version:  version,
+			Checksum: checksum,
+			CachedAt: info.ModTime(),
+			FilePath: path,
+			Size:     info.Size(),
+		}
+	}
+
+	logger.Debug("Rebuilt template cache index with %d entries", len(c.index.Entries))
+	if err := c.saveIndex(); err != nil {
+		logger.Debug("Failed to save rebuilt cache index: %v", err)
+	}
+}
+
+// parseCacheFileName splits a cache file name into the AI tool, version and
+// checksum prefix it was created with. The version may itself contain dashes.
+func parseCacheFileName(name string) (aiTool, version, shortSum string, ok bool) {
+	base := strings.TrimSuffix(name, ".zip")
+	first := strings.Index(base, "-")
+	last := strings.LastIndex(base, "-")
+	if first < 0 || last <= first+1 {
+		return "", "", "", false
+	}
+
+	aiTool, version, shortSum = base[:first], base[first+1:last], base[last+1:]
+	if !config.IsValidAITool(aiTool) || len(shortSum) != 8 {
+		return "", "", "", false
+	}
+	if _, err := hex.DecodeString(shortSum); err != nil {
+		return "", "", "", false
+	}
+	return aiTool, version, shortSum, true
+}
+
+// fileChecksum returns the hex sha256 of a file's contents
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 // Get retrieves a cached template if available and not expired
 func (c *Cache) Get(aiTool string, ttl time.Duration) (*CacheEntry, error) {
 	entry, exists := c.index.Entries[aiTool]
@@ -182,6 +275,58 @@ func (c *Cache) Put(aiTool, version, originalURL string, data []byte) error {
 	return nil
 }
 
+// PutFile stores a downloaded template in the cache, recording checksum (as
+// returned by Download) so VerifyIntegrity can later detect corruption
+func (c *Cache) PutFile(aiTool, version, originalURL, srcPath, checksum string) error {
+	if len(checksum) < 8 {
+		return fmt.Errorf("invalid checksum for %s: %q", srcPath, checksum)
+	}
+
+	// Generate cache file path
+	cacheFileName := fmt.Sprintf("%s-%s-%s.zip", aiTool, version, checksum[:8])
+	cachePath := filepath.Join(c.cacheDir, cacheFileName)
+
+	// Copy the downloaded file into the cache
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to open template file: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(cachePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	size, err := io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(cachePath)
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+
+	// Update index
+	c.index.Entries[aiTool] = CacheEntry{
+		AITool:      aiTool,
+		Version:     version,
+		Checksum:    checksum,
+		CachedAt:    time.Now(),
+		FilePath:    cachePath,
+		OriginalURL: originalURL,
+		Size:        size,
+	}
+
+	if err := c.saveIndex(); err != nil {
+		// Try to clean up the cache file
+		os.Remove(cachePath)
+		return err
+	}
+
+	logger.Debug("Cached template %s (version %s, size %d bytes, sha256 %s)", aiTool, version, size, checksum)
+	return nil
+}
+
 // GetCachedFile returns an io.ReadCloser for a cached template
 func (c *Cache) GetCachedFile(aiTool string) (io.ReadCloser, error) {
 	entry, exists := c.index.Entries[aiTool]
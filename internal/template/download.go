@@ -1,8 +1,10 @@
 package template
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,25 +17,32 @@ import (
 	"github.com/momorph/cli/internal/utils"
 )
 
+// ErrDownloadForbidden is returned by Download when the server answers 403,
+// which for a presigned URL usually means it has expired
+var ErrDownloadForbidden = errors.New("access denied")
+
 // ProgressCallback is a function called to report download progress
 type ProgressCallback func(downloaded, total int64)
 
-// Download downloads a template from the given URL
-func Download(url, checksum string, progress ProgressCallback) (string, error) {
+// Download downloads a template from the given URL. It returns the path of
+// the downloaded file and its SHA256 checksum, computed while streaming; if
+// checksum is non-empty the download must match it. Cancelling ctx aborts
+// the transfer and removes the partial file.
+func Download(ctx context.Context, url, checksum string, progress ProgressCallback) (string, string, error) {
 	// Validate URL
 	if !strings.HasPrefix(url, "https://") {
-		return "", fmt.Errorf("invalid URL: must use HTTPS")
+		return "", "", fmt.Errorf("invalid URL: must use HTTPS")
 	}
 
 	// Ensure cache directory exists
 	if err := config.EnsureTemplatesDir(); err != nil {
-		return "", fmt.Errorf("failed to create cache directory: %w", err)
+		return "", "", fmt.Errorf("failed to create cache directory: %w", err)
 	}
 
 	// Create temporary file for download
 	tempFile, err := os.CreateTemp(config.GetTemplatesDir(), "template-*.zip.tmp")
 	if err != nil {
-		return "", fmt.Errorf("failed to create temporary file: %w", err)
+		return "", "", fmt.Errorf("failed to create temporary file: %w", err)
 	}
 	tempPath := tempFile.Name()
 
@@ -44,31 +53,38 @@ func Download(url, checksum string, progress ProgressCallback) (string, error) {
 	}
 
 	// Create HTTP client and request
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		cleanup()
+		return "", "", fmt.Errorf("failed to create request: %w", err)
+	}
 	client := utils.NewHTTPClient()
-	resp, err := client.Get(url)
+	resp, err := client.Do(req)
 	if err != nil {
 		cleanup()
-		return "", fmt.Errorf("failed to download: %w", err)
+		return "", "", fmt.Errorf("failed to download: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Check response status
+	if resp.StatusCode == http.StatusForbidden {
+		cleanup()
+		return "", "", fmt.Errorf("download failed with status %d: %w", resp.StatusCode, ErrDownloadForbidden)
+	}
 	if resp.StatusCode != http.StatusOK {
 		cleanup()
-		return "", fmt.Errorf("download failed with status %d", resp.StatusCode)
+		return "", "", fmt.Errorf("download failed with status %d", resp.StatusCode)
 	}
 
 	// Get content length
 	totalSize := resp.ContentLength
 
 	// Create progress reader
-	var reader io.Reader = resp.Body
-	if progress != nil {
-		reader = &progressReader{
-			reader:   resp.Body,
-			total:    totalSize,
-			callback: progress,
-		}
+	reader := &progressReader{
+		ctx:      ctx,
+		reader:   resp.Body,
+		total:    totalSize,
+		callback: progress,
 	}
 
 	// Create hash writer for checksum verification
@@ -79,15 +95,15 @@ func Download(url, checksum string, progress ProgressCallback) (string, error) {
 	_, err = io.Copy(multiWriter, reader)
 	if err != nil {
 		cleanup()
-		return "", fmt.Errorf("failed to download file: %w", err)
+		return "", "", fmt.Errorf("failed to download file: %w", err)
 	}
 
 	// Verify checksum if provided
+	computedChecksum := hex.EncodeToString(hasher.Sum(nil))
 	if checksum != "" {
-		computedChecksum := hex.EncodeToString(hasher.Sum(nil))
 		if computedChecksum != checksum {
 			cleanup()
-			return "", fmt.Errorf("checksum mismatch: expected %s, got %s", checksum, computedChecksum)
+			return "", "", fmt.Errorf("checksum mismatch: expected %s, got %s", checksum, computedChecksum)
 		}
 		logger.Debug("Checksum verified: %s", checksum)
 	}
@@ -95,22 +111,24 @@ func Download(url, checksum string, progress ProgressCallback) (string, error) {
 	// Close temp file BEFORE renaming (required on Windows)
 	if err := tempFile.Close(); err != nil {
 		os.Remove(tempPath)
-		return "", fmt.Errorf("failed to close temp file: %w", err)
+		return "", "", fmt.Errorf("failed to close temp file: %w", err)
 	}
 
 	// Move temp file to final location
 	finalPath := strings.TrimSuffix(tempPath, ".tmp")
 	if err := os.Rename(tempPath, finalPath); err != nil {
 		os.Remove(tempPath)
-		return "", fmt.Errorf("failed to save file: %w", err)
+		return "", "", fmt.Errorf("failed to save file: %w", err)
 	}
 
-	logger.Info("Downloaded template to: %s", finalPath)
-	return finalPath, nil
+	logger.Info("Downloaded template to: %s (sha256 %s)", finalPath, computedChecksum)
+	return finalPath, computedChecksum, nil
 }
 
-// progressReader wraps an io.Reader to report progress
+// progressReader wraps an io.Reader to report progress and stop as soon as
+// ctx is cancelled
 type progressReader struct {
+	ctx        context.Context
 	reader     io.Reader
 	total      int64
 	downloaded int64
@@ -119,6 +137,9 @@ type progressReader struct {
 }
 
 func (pr *progressReader) Read(p []byte) (int, error) {
+	if err := pr.ctx.Err(); err != nil {
+		return 0, err
+	}
 	n, err := pr.reader.Read(p)
 	pr.downloaded += int64(n)
 
@@ -11,18 +11,22 @@ import (
 	"github.com/momorph/cli/internal/logger"
 )
 
-// ExtractWithMerge extracts a ZIP file to the target directory, merging config files instead of overwriting
-func ExtractWithMerge(zipPath, targetDir string) error {
+// ExtractWithMerge extracts a ZIP file to the target directory, merging config files instead of overwriting.
+// It returns the files and directories it created (including on error), which
+// CleanupPartial can remove without touching anything that existed before.
+func ExtractWithMerge(zipPath, targetDir string) ([]string, error) {
+	var created []string
+
 	// Open ZIP file
 	reader, err := zip.OpenReader(zipPath)
 	if err != nil {
-		return fmt.Errorf("failed to open ZIP file: %w", err)
+		return created, fmt.Errorf("failed to open ZIP file: %w", err)
 	}
 	defer reader.Close()
 
 	// Ensure target directory exists
-	if err := os.MkdirAll(targetDir, 0755); err != nil {
-		return fmt.Errorf("failed to create target directory: %w", err)
+	if err := mkdirAllTracked(targetDir, 0755, &created); err != nil {
+		return created, fmt.Errorf("failed to create target directory: %w", err)
 	}
 
 	// Clean target directory path for security checks
@@ -37,7 +41,7 @@ func ExtractWithMerge(zipPath, targetDir string) error {
 		// Validate path doesn't escape target directory (path traversal protection)
 		cleanPath := filepath.Clean(targetPath)
 		if !strings.HasPrefix(cleanPath, cleanTarget) {
-			return fmt.Errorf("invalid file path: %s (path traversal attempt)", file.Name)
+			return created, fmt.Errorf("invalid file path: %s (path traversal attempt)", file.Name)
 		}
 
 		mergeType, shouldMerge := ShouldMerge(relativePath)
@@ -51,8 +55,8 @@ func ExtractWithMerge(zipPath, targetDir string) error {
 		}
 
 		// Extract normally
-		if err := extractFile(file, cleanTarget); err != nil {
-			return fmt.Errorf("failed to extract %s: %w", file.Name, err)
+		if err := extractFile(file, cleanTarget, &created); err != nil {
+			return created, fmt.Errorf("failed to extract %s: %w", file.Name, err)
 		}
 	}
 
@@ -64,8 +68,8 @@ func ExtractWithMerge(zipPath, targetDir string) error {
 		if err := mergeFileFromZip(zipFile, targetPath, mergeType); err != nil {
 			logger.Warn("Failed to merge %s, overwriting instead: %v", relativePath, err)
 			// Fallback to overwrite on merge failure
-			if err := extractFile(zipFile, cleanTarget); err != nil {
-				return fmt.Errorf("failed to extract %s: %w", zipFile.Name, err)
+			if err := extractFile(zipFile, cleanTarget, &created); err != nil {
+				return created, fmt.Errorf("failed to extract %s: %w", zipFile.Name, err)
 			}
 		} else {
 			logger.Info("Merged: %s", relativePath)
@@ -73,9 +77,83 @@ func ExtractWithMerge(zipPath, targetDir string) error {
 	}
 
 	logger.Info("Extracted %d files to: %s (merged %d config files)", len(reader.File), targetDir, len(mergeQueue))
+	return created, nil
+}
+
+// CheckArchive verifies that zipPath is a readable, non-empty ZIP file whose
+// entries all stay inside the directory they are extracted to, so a local
+// template can be rejected before anything is written.
+func CheckArchive(zipPath string) error {
+	reader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return fmt.Errorf("not a readable ZIP file: %w", err)
+	}
+	defer reader.Close()
+
+	if len(reader.File) == 0 {
+		return fmt.Errorf("ZIP file is empty")
+	}
+
+	// Same path traversal protection as ExtractWithMerge, against a
+	// placeholder target directory
+	cleanTarget := filepath.Clean(string(filepath.Separator) + "target")
+	for _, file := range reader.File {
+		cleanPath := filepath.Clean(filepath.Join(cleanTarget, file.Name))
+		if !strings.HasPrefix(cleanPath, cleanTarget) {
+			return fmt.Errorf("invalid file path: %s (path traversal attempt)", file.Name)
+		}
+	}
 	return nil
 }
 
+// Actions reported by PlanExtraction for each file
+const (
+	PlanNew       = "new"
+	PlanMerge     = "merge"
+	PlanOverwrite = "overwrite"
+)
+
+// PlannedFile is a ZIP entry and what extracting it would do
+type PlannedFile struct {
+	Name   string // path inside the ZIP, relative to the target directory
+	Target string // path the file would be written to
+	Action string // PlanNew, PlanMerge or PlanOverwrite
+}
+
+// PlanExtraction reports what ExtractWithMerge would do for each file in
+// the ZIP, using the same merge rules and path traversal protection, without
+// writing anything.
+func PlanExtraction(zipPath, targetDir string) ([]PlannedFile, error) {
+	reader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ZIP file: %w", err)
+	}
+	defer reader.Close()
+
+	cleanTarget := filepath.Clean(targetDir)
+	var plan []PlannedFile
+	for _, file := range reader.File {
+		targetPath := filepath.Join(cleanTarget, file.Name)
+		cleanPath := filepath.Clean(targetPath)
+		if !strings.HasPrefix(cleanPath, cleanTarget) {
+			return nil, fmt.Errorf("invalid file path: %s (path traversal attempt)", file.Name)
+		}
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		action := PlanNew
+		if fileExists(targetPath) {
+			action = PlanOverwrite
+			if _, shouldMerge := ShouldMerge(file.Name); shouldMerge {
+				action = PlanMerge
+			}
+		}
+		plan = append(plan, PlannedFile{Name: file.Name, Target: cleanPath, Action: action})
+	}
+	return plan, nil
+}
+
 // mergeFileFromZip extracts a file from ZIP to temp location and merges it with existing file
 func mergeFileFromZip(zipFile *zip.File, existingPath string, mergeType MergeType) error {
 	// Extract to temp file
@@ -139,7 +217,7 @@ func Extract(zipPath, targetDir string) error {
 
 	// Extract files
 	for _, file := range reader.File {
-		if err := extractFile(file, cleanTarget); err != nil {
+		if err := extractFile(file, cleanTarget, nil); err != nil {
 			return fmt.Errorf("failed to extract %s: %w", file.Name, err)
 		}
 	}
@@ -148,8 +226,9 @@ func Extract(zipPath, targetDir string) error {
 	return nil
 }
 
-// extractFile extracts a single file from the ZIP
-func extractFile(file *zip.File, targetDir string) error {
+// extractFile extracts a single file from the ZIP.
+// Paths that did not exist before are appended to created, if not nil.
+func extractFile(file *zip.File, targetDir string, created *[]string) error {
 	// Build target path
 	targetPath := filepath.Join(targetDir, file.Name)
 
@@ -161,14 +240,16 @@ func extractFile(file *zip.File, targetDir string) error {
 
 	// Check if it's a directory
 	if file.FileInfo().IsDir() {
-		return os.MkdirAll(targetPath, file.Mode())
+		return mkdirAllTracked(targetPath, file.Mode(), created)
 	}
 
 	// Ensure parent directory exists
-	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+	if err := mkdirAllTracked(filepath.Dir(targetPath), 0755, created); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
+	existed := pathExists(targetPath)
+
 	// Open file in ZIP
 	srcFile, err := file.Open()
 	if err != nil {
@@ -183,6 +264,10 @@ func extractFile(file *zip.File, targetDir string) error {
 	}
 	defer dstFile.Close()
 
+	if !existed && created != nil {
+		*created = append(*created, targetPath)
+	}
+
 	// Copy file contents
 	if _, err := io.Copy(dstFile, srcFile); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
@@ -191,22 +276,61 @@ func extractFile(file *zip.File, targetDir string) error {
 	return nil
 }
 
-// CleanupPartial removes partially extracted files on error
-func CleanupPartial(targetDir string) error {
-	// Check if directory exists and is not empty
-	entries, err := os.ReadDir(targetDir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil // Nothing to clean up
+// mkdirAllTracked works like os.MkdirAll and appends every directory it
+// creates to created, parents first, if created is not nil
+func mkdirAllTracked(path string, perm os.FileMode, created *[]string) error {
+	var missing []string
+	for dir := filepath.Clean(path); !pathExists(dir); dir = filepath.Dir(dir) {
+		missing = append(missing, dir)
+		if filepath.Dir(dir) == dir {
+			break
 		}
-		return err
 	}
 
-	// Only clean up if there are files (partial extraction)
-	if len(entries) > 0 {
-		logger.Debug("Cleaning up partial extraction: %s", targetDir)
-		return os.RemoveAll(targetDir)
+	if err := os.MkdirAll(path, perm); err != nil {
+		return err
 	}
 
+	if created != nil {
+		for i := len(missing) - 1; i >= 0; i-- {
+			*created = append(*created, missing[i])
+		}
+	}
 	return nil
 }
+
+// pathExists reports whether a file or directory exists at path
+func pathExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// CleanupPartial removes the files and directories created by a failed
+// extraction, as returned by ExtractWithMerge. Paths that existed before the
+// extraction are never in the list, and directories that still contain other
+// files are left in place.
+func CleanupPartial(created []string) error {
+	var firstErr error
+
+	// Remove in reverse order so files go before the directories holding them
+	for i := len(created) - 1; i >= 0; i-- {
+		path := created[i]
+		info, err := os.Lstat(path)
+		if err != nil {
+			continue // Already gone
+		}
+
+		if err := os.Remove(path); err != nil {
+			if info.IsDir() {
+				continue // Not empty: holds files we did not create
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		logger.Debug("Removed partially extracted path: %s", path)
+	}
+
+	return firstErr
+}
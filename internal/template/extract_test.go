@@ -0,0 +1,147 @@
+package template
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestZip writes a zip holding files (name to content, in order) to a
+// temporary file and returns its path
+func writeTestZip(t *testing.T, files [][2]string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.Create(f[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(f[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "template.zip")
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// writeTestFiles creates files (relative path to content) under dir
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// existingRepo is a populated directory that "init ." is run in
+var existingRepo = map[string]string{
+	".git/HEAD":             "ref: refs/heads/main\n",
+	"README.md":             "# My project\n",
+	"src/main.go":           "package main\n",
+	".gitignore":            "node_modules/\n",
+	".vscode/settings.json": "{\n  \"editor.tabSize\": 2\n}\n",
+}
+
+func TestExtractWithMergeFailureKeepsExistingFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, existingRepo)
+
+	// The last entry escapes the target, so extraction fails after the
+	// first files were written
+	zipPath := writeTestZip(t, [][2]string{
+		{".momorph/constitution.md", "# Constitution\n"},
+		{".github/prompts/momorph.specs.prompt.md", "prompt\n"},
+		{"src/generated.txt", "new file in an existing directory\n"},
+		{".gitignore", ".momorph/cache/\n"},
+		{"../escape.txt", "outside\n"},
+	})
+
+	created, err := ExtractWithMerge(zipPath, dir)
+	if err == nil {
+		t.Fatal("ExtractWithMerge succeeded, want a path traversal error")
+	}
+	if err := CleanupPartial(created); err != nil {
+		t.Fatalf("CleanupPartial: %v", err)
+	}
+
+	// Everything that was there before is untouched
+	for name, want := range existingRepo {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("%s was removed: %v", name, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+
+	// Everything the extraction created is gone, including new directories
+	for _, name := range []string{".momorph", ".github", "src/generated.txt"} {
+		if _, err := os.Lstat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("%s was not cleaned up", name)
+		}
+	}
+	if _, err := os.Lstat(filepath.Join(filepath.Dir(dir), "escape.txt")); !os.IsNotExist(err) {
+		t.Error("file outside the target directory was written")
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("target directory was removed: %v", err)
+	}
+}
+
+func TestExtractWithMergeIntoExistingRepo(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, existingRepo)
+
+	zipPath := writeTestZip(t, [][2]string{
+		{".momorph/constitution.md", "# Constitution\n"},
+		{".gitignore", ".momorph/cache/\n"},
+		{".vscode/settings.json", "{\n  \"files.eol\": \"\\n\"\n}\n"},
+	})
+
+	created, err := ExtractWithMerge(zipPath, dir)
+	if err != nil {
+		t.Fatalf("ExtractWithMerge: %v", err)
+	}
+
+	// Only new paths are reported as created
+	for _, path := range created {
+		rel, _ := filepath.Rel(dir, path)
+		if _, existed := existingRepo[filepath.ToSlash(rel)]; existed {
+			t.Errorf("pre-existing %s reported as created", rel)
+		}
+	}
+
+	for _, name := range []string{".git/HEAD", "README.md", "src/main.go"} {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(got) != existingRepo[name] {
+			t.Errorf("%s = %q, %v; want it unchanged", name, got, err)
+		}
+	}
+
+	gitignore, _ := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	if !bytes.Contains(gitignore, []byte("node_modules/")) || !bytes.Contains(gitignore, []byte(".momorph/cache/")) {
+		t.Errorf(".gitignore = %q, want both entries", gitignore)
+	}
+	settings, _ := os.ReadFile(filepath.Join(dir, ".vscode/settings.json"))
+	if !bytes.Contains(settings, []byte(`"editor.tabSize": 2`)) || !bytes.Contains(settings, []byte(`"files.eol"`)) {
+		t.Errorf("settings.json = %q, want both settings", settings)
+	}
+}
@@ -0,0 +1,448 @@
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// JSONC is JSON with // and /* */ comments and trailing commas, as accepted
+// by VS Code for settings.json and by most AI tools for their MCP configs.
+// The helpers below edit such files in place, so comments, key order and
+// formatting of everything that is not changed are preserved.
+
+// stripJSONC returns data with comments and trailing commas replaced by
+// spaces. Offsets are unchanged, so positions found in the result apply to
+// the original data.
+func stripJSONC(data []byte) []byte {
+	out := stripJSONCComments(data)
+
+	// Blank out commas that are followed only by whitespace and a closing
+	// bracket; outside strings, since comments are already gone
+	inString := false
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+		if inString {
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		if c == '"' {
+			inString = true
+			continue
+		}
+		if c != ',' {
+			continue
+		}
+		j := i + 1
+		for j < len(out) && isJSONSpace(out[j]) {
+			j++
+		}
+		if j < len(out) && (out[j] == '}' || out[j] == ']') {
+			out[i] = ' '
+		}
+	}
+	return out
+}
+
+// stripJSONCComments returns a copy of data with comments replaced by
+// spaces, keeping newlines so line positions are unchanged
+func stripJSONCComments(data []byte) []byte {
+	out := make([]byte, len(data))
+	copy(out, data)
+
+	inString := false
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+		if inString {
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch {
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			for i < len(out) && out[i] != '\n' {
+				out[i] = ' '
+				i++
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			out[i], out[i+1] = ' ', ' '
+			i += 2
+			for i < len(out) && !(out[i] == '*' && i+1 < len(out) && out[i+1] == '/') {
+				if out[i] != '\n' {
+					out[i] = ' '
+				}
+				i++
+			}
+			if i < len(out) {
+				out[i], out[i+1] = ' ', ' '
+				i++
+			}
+		}
+	}
+	return out
+}
+
+// parseJSONC unmarshals JSON or JSONC data into v
+func parseJSONC(data []byte, v interface{}) error {
+	return json.Unmarshal(stripJSONC(data), v)
+}
+
+// jsonNode is a value in a parsed JSON document, located by its offsets
+type jsonNode struct {
+	start, end int // span of the value, end exclusive
+	isObject   bool
+	keys       []string             // object keys in document order
+	keyStarts  []int                // offset of each key's opening quote
+	fields     map[string]*jsonNode // object members by key
+}
+
+// parseJSONDocument parses data, which must be valid JSON once stripped of
+// comments and trailing commas, into a tree of located values
+func parseJSONDocument(data []byte) (*jsonNode, error) {
+	clean := stripJSONC(data)
+	if !json.Valid(clean) {
+		// Unmarshal again only to produce a descriptive error
+		var v interface{}
+		if err := json.Unmarshal(clean, &v); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("invalid JSON")
+	}
+	node, _ := parseJSONValue(clean, skipJSONSpace(clean, 0))
+	return node, nil
+}
+
+// parseJSONValue parses the value starting at pos in valid JSON and returns
+// it with the offset just after it
+func parseJSONValue(data []byte, pos int) (*jsonNode, int) {
+	node := &jsonNode{start: pos}
+	switch data[pos] {
+	case '{':
+		node.isObject = true
+		node.fields = make(map[string]*jsonNode)
+		pos = skipJSONSpace(data, pos+1)
+		for data[pos] != '}' {
+			keyStart := pos
+			keyEnd := skipJSONString(data, pos)
+			var key string
+			json.Unmarshal(data[keyStart:keyEnd], &key)
+			pos = skipJSONSpace(data, keyEnd)
+			pos = skipJSONSpace(data, pos+1) // ':'
+			value, next := parseJSONValue(data, pos)
+			node.keys = append(node.keys, key)
+			node.keyStarts = append(node.keyStarts, keyStart)
+			node.fields[key] = value
+			pos = skipJSONSpace(data, next)
+			if data[pos] == ',' {
+				pos = skipJSONSpace(data, pos+1)
+			}
+		}
+		pos++
+	case '[':
+		pos = skipJSONSpace(data, pos+1)
+		for data[pos] != ']' {
+			_, next := parseJSONValue(data, pos)
+			pos = skipJSONSpace(data, next)
+			if data[pos] == ',' {
+				pos = skipJSONSpace(data, pos+1)
+			}
+		}
+		pos++
+	case '"':
+		pos = skipJSONString(data, pos)
+	default:
+		for pos < len(data) && !isJSONSpace(data[pos]) && !strings.ContainsRune(",}]", rune(data[pos])) {
+			pos++
+		}
+	}
+	node.end = pos
+	return node, pos
+}
+
+// skipJSONString returns the offset just after the string starting at pos
+func skipJSONString(data []byte, pos int) int {
+	for i := pos + 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(data)
+}
+
+func skipJSONSpace(data []byte, pos int) int {
+	for pos < len(data) && isJSONSpace(data[pos]) {
+		pos++
+	}
+	return pos
+}
+
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// jsonEdit replaces data[from:to] with text
+type jsonEdit struct {
+	from, to int
+	text     string
+}
+
+// applyJSONEdits applies non-overlapping edits to data
+func applyJSONEdits(data []byte, edits []jsonEdit) []byte {
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].from > edits[j].from })
+	out := append([]byte(nil), data...)
+	for _, e := range edits {
+		out = append(out[:e.from], append([]byte(e.text), out[e.to:]...)...)
+	}
+	return out
+}
+
+// lineIndent returns the leading whitespace of the line containing pos
+func lineIndent(data []byte, pos int) string {
+	start := bytes.LastIndexByte(data[:pos], '\n') + 1
+	end := start
+	for end < len(data) && (data[end] == ' ' || data[end] == '\t') {
+		end++
+	}
+	return string(data[start:end])
+}
+
+// onOwnLine reports whether only whitespace precedes pos on its line
+func onOwnLine(data []byte, pos int) bool {
+	start := bytes.LastIndexByte(data[:pos], '\n') + 1
+	return len(bytes.TrimSpace(data[start:pos])) == 0
+}
+
+// lineEnding returns the line ending data uses, "\r\n" or "\n"
+func lineEnding(data []byte) string {
+	if bytes.Contains(data, []byte("\r\n")) {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// indentUnit guesses the indentation step of a document from its root object
+func indentUnit(data []byte, root *jsonNode) string {
+	if len(root.keyStarts) > 0 && onOwnLine(data, root.keyStarts[0]) {
+		if indent := lineIndent(data, root.keyStarts[0]); indent != "" {
+			return indent
+		}
+	}
+	return "  "
+}
+
+// addMembersEdits returns the edits that append members (key and raw JSON
+// value pairs) to the object obj in data, matching its layout and line
+// endings
+func addMembersEdits(data []byte, obj *jsonNode, keys []string, values [][]byte, unit string) []jsonEdit {
+	newline := lineEnding(data)
+	closePos := obj.end - 1
+	closingIndent := lineIndent(data, obj.start)
+	if onOwnLine(data, closePos) {
+		closingIndent = lineIndent(data, closePos)
+	}
+
+	// Single-line objects stay on one line
+	if len(obj.keys) > 0 && !onOwnLine(data, obj.keyStarts[0]) {
+		var b strings.Builder
+		for i, key := range keys {
+			var compact bytes.Buffer
+			json.Compact(&compact, values[i])
+			keyJSON, _ := json.Marshal(key)
+			fmt.Fprintf(&b, ", %s: %s", keyJSON, compact.Bytes())
+		}
+		last := obj.fields[obj.keys[len(obj.keys)-1]]
+		return []jsonEdit{{from: last.end, to: last.end, text: b.String()}}
+	}
+
+	indent := closingIndent + unit
+	if len(obj.keys) > 0 {
+		indent = lineIndent(data, obj.keyStarts[0])
+	}
+	entries := make([]string, len(keys))
+	for i, key := range keys {
+		var compact, indented bytes.Buffer
+		json.Compact(&compact, values[i])
+		json.Indent(&indented, compact.Bytes(), indent, unit)
+		keyJSON, _ := json.Marshal(key)
+		// Newlines only come from Indent: those in strings are escaped
+		value := strings.ReplaceAll(indented.String(), "\n", newline)
+		entries[i] = fmt.Sprintf("%s%s: %s", indent, keyJSON, value)
+	}
+	members := strings.Join(entries, ","+newline)
+
+	if len(obj.keys) == 0 {
+		// Replace the blank interior of an empty object
+		from, to := obj.start+1, closePos
+		if len(bytes.TrimSpace(data[from:to])) != 0 {
+			from = to // keep comments inside it
+		}
+		return []jsonEdit{{from: from, to: to, text: newline + members + newline + closingIndent}}
+	}
+
+	// Add the new members after the last one, and after any comment that
+	// follows it, so the comment stays with its member
+	last := obj.fields[obj.keys[len(obj.keys)-1]]
+	insertAt := closePos
+	for insertAt > last.end && isJSONSpace(data[insertAt-1]) {
+		insertAt--
+	}
+	edits := []jsonEdit{{from: insertAt, to: insertAt, text: newline + members}}
+	if !bytes.Contains(stripJSONCComments(data[last.end:closePos]), []byte(",")) {
+		if insertAt == last.end {
+			edits[0].text = "," + edits[0].text
+		} else {
+			edits = append(edits, jsonEdit{from: last.end, to: last.end, text: ","})
+		}
+	}
+	return edits
+}
+
+// setJSONCMember sets the member at path, a key in each nested object, to
+// value in the object in data, and returns the edited document. Objects
+// missing on the way are created and values on the way that are not
+// objects are replaced; everything else is left as it is.
+func setJSONCMember(data []byte, path []string, value interface{}) ([]byte, error) {
+	root, err := parseJSONDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	if !root.isObject {
+		return nil, fmt.Errorf("document is not an object")
+	}
+	unit := indentUnit(data, root)
+
+	obj := root
+	for i, key := range path {
+		node, exists := obj.fields[key]
+		if exists && node.isObject && i < len(path)-1 {
+			obj = node
+			continue
+		}
+
+		// Build the rest of the path around value
+		nested := value
+		for j := len(path) - 1; j > i; j-- {
+			nested = map[string]interface{}{path[j]: nested}
+		}
+		raw, err := marshalJSONValue(nested)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return applyJSONEdits(data, addMembersEdits(data, obj, []string{key}, [][]byte{raw}, unit)), nil
+		}
+		return applyJSONEdits(data, []jsonEdit{replaceValueEdit(data, node, raw, unit)}), nil
+	}
+	return data, nil
+}
+
+// removeJSONCMember removes the member at path, a key in each nested
+// object, from the object in data and returns the edited document. A path
+// that does not lead to a member leaves data unchanged.
+func removeJSONCMember(data []byte, path []string) ([]byte, error) {
+	obj, err := parseJSONDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	for i, key := range path {
+		if !obj.isObject {
+			return data, nil
+		}
+		if _, exists := obj.fields[key]; !exists {
+			return data, nil
+		}
+		if i == len(path)-1 {
+			return applyJSONEdits(data, removeMemberEdits(data, obj, key)), nil
+		}
+		obj = obj.fields[key]
+	}
+	return data, nil
+}
+
+// marshalJSONValue marshals v without escaping HTML characters, which are
+// common in URLs
+func marshalJSONValue(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// replaceValueEdit returns the edit that replaces the value node in data
+// with raw JSON. A value written on one line stays on one line; otherwise
+// the new value is indented from the line it starts on.
+func replaceValueEdit(data []byte, node *jsonNode, raw []byte, unit string) jsonEdit {
+	var compact bytes.Buffer
+	json.Compact(&compact, raw)
+
+	old := data[node.start:node.end]
+	isContainer := old[0] == '{' || old[0] == '['
+	if isContainer && !bytes.Contains(old, []byte("\n")) && len(bytes.TrimSpace(stripJSONC(old[1:len(old)-1]))) > 0 {
+		return jsonEdit{from: node.start, to: node.end, text: compact.String()}
+	}
+
+	var indented bytes.Buffer
+	json.Indent(&indented, compact.Bytes(), lineIndent(data, node.start), unit)
+	// Newlines only come from Indent: those in strings are escaped
+	text := strings.ReplaceAll(indented.String(), "\n", lineEnding(data))
+	return jsonEdit{from: node.start, to: node.end, text: text}
+}
+
+// removeMemberEdits returns the edits that remove the member key, and the
+// comma that separates it, from the object obj in data. A member on a line
+// of its own is removed with its line.
+func removeMemberEdits(data []byte, obj *jsonNode, key string) []jsonEdit {
+	clean := stripJSONCComments(data)
+	index := 0
+	for index < len(obj.keys) && obj.keys[index] != key {
+		index++
+	}
+	value := obj.fields[key]
+	from, to := obj.keyStarts[index], value.end
+
+	var edits []jsonEdit
+	// The comma after the value goes with it, even a trailing one
+	limit := obj.end - 1
+	if index < len(obj.keys)-1 {
+		limit = obj.keyStarts[index+1]
+	}
+	if c := bytes.IndexByte(clean[to:limit], ','); c >= 0 {
+		to += c + 1
+	}
+	// Without a member after it, the comma before it would be left trailing
+	if index == len(obj.keys)-1 && index > 0 {
+		prevEnd := obj.fields[obj.keys[index-1]].end
+		if c := bytes.IndexByte(clean[prevEnd:from], ','); c >= 0 {
+			edits = append(edits, jsonEdit{from: prevEnd + c, to: prevEnd + c + 1})
+		}
+	}
+
+	lineEnd := bytes.IndexByte(data[to:], '\n')
+	if onOwnLine(data, from) && lineEnd >= 0 && len(bytes.TrimSpace(data[to:to+lineEnd])) == 0 {
+		from = bytes.LastIndexByte(data[:from], '\n') + 1
+		to += lineEnd + 1
+	} else if index < len(obj.keys)-1 {
+		// The spaces before the member separate the ones around it
+		for to < limit && (data[to] == ' ' || data[to] == '\t') {
+			to++
+		}
+	}
+	return append(edits, jsonEdit{from: from, to: to})
+}
@@ -0,0 +1,233 @@
+package template
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseJSONC(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string // compact JSON
+	}{
+		{"plain", `{"a": 1}`, `{"a":1}`},
+		{"line comment", "{\n  // note\n  \"a\": 1\n}", `{"a":1}`},
+		{"block comment", "{ /* a\n b */ \"a\": 1 }", `{"a":1}`},
+		{"trailing commas", "{\"a\": [1, 2,], \"b\": {\"c\": 3,},}", `{"a":[1,2],"b":{"c":3}}`},
+		{"slashes in strings", `{"url": "https://mcp.momorph.ai//x", "glob": "/* keep */"}`, `{"glob":"/* keep */","url":"https://mcp.momorph.ai//x"}`},
+		{"escaped quote before comment", `{"a": "say \"hi\"" // done` + "\n}", `{"a":"say \"hi\""}`},
+		{"comma in string", `{"a": "x,}"}`, `{"a":"x,}"}`},
+		{"CRLF comments", "{\r\n  // note\r\n  \"a\": 1,\r\n}\r\n", `{"a":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v map[string]interface{}
+			if err := parseJSONC([]byte(tt.data), &v); err != nil {
+				t.Fatalf("parseJSONC: %v", err)
+			}
+			got, _ := json.Marshal(v)
+			if string(got) != tt.want {
+				t.Errorf("parseJSONC = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeJSONC(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		template string
+		want     string
+	}{
+		{
+			name:     "adds missing keys, existing values win",
+			existing: "{\n  \"a\": 1,\n  \"b\": 2\n}\n",
+			template: `{"b": 3, "c": 4}`,
+			want:     "{\n  \"a\": 1,\n  \"b\": 2,\n  \"c\": 4\n}\n",
+		},
+		{
+			name:     "keeps comments",
+			existing: "{\n  // editor\n  \"a\": 1 // trailing\n}\n",
+			template: `{"b": true}`,
+			want:     "{\n  // editor\n  \"a\": 1, // trailing\n  \"b\": true\n}\n",
+		},
+		{
+			name:     "trailing comma",
+			existing: "{\n  \"a\": 1,\n}\n",
+			template: `{"b": 2}`,
+			want:     "{\n  \"a\": 1,\n  \"b\": 2\n}\n",
+		},
+		{
+			name:     "empty object",
+			existing: "{}\n",
+			template: `{"a": {"b": 1}}`,
+			want:     "{\n  \"a\": {\n    \"b\": 1\n  }\n}\n",
+		},
+		{
+			name:     "single-line object stays on one line",
+			existing: `{"a": 1}`,
+			template: "{\n  \"b\": {\"c\": [1, 2]}\n}",
+			want:     `{"a": 1, "b": {"c":[1,2]}}`,
+		},
+		{
+			name:     "nested merge",
+			existing: "{\n    \"outer\": {\n        \"keep\": \"mine\"\n    }\n}\n",
+			template: `{"outer": {"keep": "theirs", "add": {"deep": true}}, "top": 1}`,
+			want:     "{\n    \"outer\": {\n        \"keep\": \"mine\",\n        \"add\": {\n            \"deep\": true\n        }\n    },\n    \"top\": 1\n}\n",
+		},
+		{
+			name:     "strings containing comment markers",
+			existing: "{\n  \"url\": \"https://example.com//a\", /* real comment */\n  \"glob\": \"src/**/*.go\"\n}\n",
+			template: `{"mcp": "https://mcp.momorph.ai"}`,
+			want:     "{\n  \"url\": \"https://example.com//a\", /* real comment */\n  \"glob\": \"src/**/*.go\",\n  \"mcp\": \"https://mcp.momorph.ai\"\n}\n",
+		},
+		{
+			name:     "CRLF file keeps CRLF",
+			existing: "{\r\n  \"a\": 1\r\n}\r\n",
+			template: `{"b": {"c": 2}}`,
+			want:     "{\r\n  \"a\": 1,\r\n  \"b\": {\r\n    \"c\": 2\r\n  }\r\n}\r\n",
+		},
+		{
+			name:     "CRLF empty object",
+			existing: "{\r\n}\r\n",
+			template: `{"a": 1}`,
+			want:     "{\r\n  \"a\": 1\r\n}\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeJSONC([]byte(tt.existing), []byte(tt.template))
+			if err != nil {
+				t.Fatalf("mergeJSONC: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("mergeJSONC =\n%q\nwant\n%q", got, tt.want)
+			}
+			if strings.Contains(tt.existing, "\r\n") && strings.Count(string(got), "\n") != strings.Count(string(got), "\r\n") {
+				t.Errorf("merged CRLF file has bare LF line endings: %q", got)
+			}
+			var v interface{}
+			if err := parseJSONC(got, &v); err != nil {
+				t.Errorf("merged document does not parse: %v", err)
+			}
+		})
+	}
+}
+
+func TestMergeJSONCRejectsNonObjects(t *testing.T) {
+	if _, err := mergeJSONC([]byte(`[1]`), []byte(`{"a": 1}`)); err == nil {
+		t.Error("mergeJSONC accepted an array")
+	}
+	if _, err := mergeJSONC([]byte(`{"a": `), []byte(`{"a": 1}`)); err == nil {
+		t.Error("mergeJSONC accepted invalid JSON")
+	}
+}
+
+func TestSetJSONCMember(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		path  []string
+		value interface{}
+		want  string
+	}{
+		{
+			name:  "replaces a value and keeps comments",
+			data:  "{\n  // servers\n  \"mcpServers\": {\n    \"momorph\": {\n      \"url\": \"https://old\" // endpoint\n    }\n  }\n}\n",
+			path:  []string{"mcpServers", "momorph", "url"},
+			value: "https://mcp?a=1&b=2",
+			want:  "{\n  // servers\n  \"mcpServers\": {\n    \"momorph\": {\n      \"url\": \"https://mcp?a=1&b=2\" // endpoint\n    }\n  }\n}\n",
+		},
+		{
+			name:  "creates missing objects",
+			data:  "{\n  \"other\": 1 // keep\n}\n",
+			path:  []string{"mcpServers", "momorph"},
+			value: map[string]string{"url": "https://mcp"},
+			want:  "{\n  \"other\": 1, // keep\n  \"mcpServers\": {\n    \"momorph\": {\n      \"url\": \"https://mcp\"\n    }\n  }\n}\n",
+		},
+		{
+			name:  "replaces an object",
+			data:  "{\n  \"mcpServers\": {\n    \"momorph\": {\"url\": \"https://old\", \"stale\": true},\n    \"other\": {}\n  }\n}\n",
+			path:  []string{"mcpServers", "momorph"},
+			value: map[string]string{"url": "https://mcp"},
+			want:  "{\n  \"mcpServers\": {\n    \"momorph\": {\"url\":\"https://mcp\"},\n    \"other\": {}\n  }\n}\n",
+		},
+		{
+			name:  "replaces null on the path",
+			data:  "{\r\n  \"headers\": null\r\n}\r\n",
+			path:  []string{"headers", "x-github-token"},
+			value: "gho_new",
+			want:  "{\r\n  \"headers\": {\r\n    \"x-github-token\": \"gho_new\"\r\n  }\r\n}\r\n",
+		},
+		{
+			name:  "empty document",
+			data:  "{}\n",
+			path:  []string{"mcpServers", "momorph"},
+			value: map[string]string{"url": "https://mcp"},
+			want:  "{\n  \"mcpServers\": {\n    \"momorph\": {\n      \"url\": \"https://mcp\"\n    }\n  }\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := setJSONCMember([]byte(tt.data), tt.path, tt.value)
+			if err != nil {
+				t.Fatalf("setJSONCMember: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("setJSONCMember =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveJSONCMember(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "first member",
+			data: "{\n  \"momorph\": {\n    \"url\": \"x\"\n  },\n  // other server\n  \"other\": 1\n}\n",
+			want: "{\n  // other server\n  \"other\": 1\n}\n",
+		},
+		{
+			name: "last member",
+			data: "{\n  \"other\": 1, // keep\n  \"momorph\": {\"url\": \"x\"}\n}\n",
+			want: "{\n  \"other\": 1 // keep\n}\n",
+		},
+		{
+			name: "trailing comma",
+			data: "{\n  \"other\": 1,\n  \"momorph\": 2,\n}\n",
+			want: "{\n  \"other\": 1\n}\n",
+		},
+		{
+			name: "single line",
+			data: `{"a": 1, "momorph": 2, "b": 3}`,
+			want: `{"a": 1, "b": 3}`,
+		},
+		{
+			name: "missing member",
+			data: `{"a": 1}`,
+			want: `{"a": 1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := removeJSONCMember([]byte(tt.data), []string{"momorph"})
+			if err != nil {
+				t.Fatalf("removeJSONCMember: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("removeJSONCMember =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
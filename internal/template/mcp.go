@@ -1,10 +1,14 @@
 package template
 
 import (
-	"encoding/json"
+	"bytes"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/momorph/cli/internal/logger"
 )
@@ -12,6 +16,9 @@ import (
 // ConfigUpdater defines the interface for updating AI tool specific configs
 type ConfigUpdater interface {
 	ConfigureMCPServer(projectDir, githubToken, mcpServerEndpoint string) error
+	// ConfigPath returns the MCP config file the updater writes, or "" if
+	// the tool has none
+	ConfigPath(projectDir string) (string, error)
 }
 
 // ClaudeMCPConfig represents the structure of Claude's .mcp.json file
@@ -29,10 +36,15 @@ type ClaudeMCPServer struct {
 // claudeConfigUpdater handles Claude-specific config updates
 type claudeConfigUpdater struct{}
 
+// ConfigPath returns the project's .mcp.json
+func (c *claudeConfigUpdater) ConfigPath(projectDir string) (string, error) {
+	return filepath.Join(projectDir, ".mcp.json"), nil
+}
+
 // ConfigureMCPServer updates the GitHub token in Claude's .mcp.json file
 // This function preserves all existing fields and only updates the x-github-token value
 func (c *claudeConfigUpdater) ConfigureMCPServer(projectDir, githubToken, mcpServerEndpoint string) error {
-	mcpFilePath := filepath.Join(projectDir, ".mcp.json")
+	mcpFilePath, _ := c.ConfigPath(projectDir)
 
 	// Check if .mcp.json exists
 	if _, err := os.Stat(mcpFilePath); os.IsNotExist(err) {
@@ -40,18 +52,13 @@ func (c *claudeConfigUpdater) ConfigureMCPServer(projectDir, githubToken, mcpSer
 		return nil // Not an error, just skip
 	}
 
-	// Read .mcp.json file
-	data, err := os.ReadFile(mcpFilePath)
+	// Read .mcp.json; it belongs to the project, so an unparseable file is
+	// an error rather than replaced
+	data, mcpConfig, err := readMCPConfig(mcpFilePath, false)
 	if err != nil {
 		return fmt.Errorf("failed to read .mcp.json: %w", err)
 	}
 
-	// Parse JSON as generic map to preserve all fields
-	var mcpConfig map[string]interface{}
-	if err := json.Unmarshal(data, &mcpConfig); err != nil {
-		return fmt.Errorf("failed to parse .mcp.json: %w", err)
-	}
-
 	// Navigate to mcpServers
 	serversInterface, exists := mcpConfig["mcpServers"]
 	if !exists {
@@ -76,33 +83,28 @@ func (c *claudeConfigUpdater) ConfigureMCPServer(projectDir, githubToken, mcpSer
 		return fmt.Errorf("momorph server is not a valid object")
 	}
 
-	// Get or create headers
-	var headers map[string]interface{}
-	headersInterface, exists := momorphServer["headers"]
-	if !exists || headersInterface == nil {
-		headers = make(map[string]interface{})
-		momorphServer["headers"] = headers
-	} else {
-		headers, ok = headersInterface.(map[string]interface{})
-		if !ok {
+	// Headers are created when missing or null
+	if headersInterface := momorphServer["headers"]; headersInterface != nil {
+		if _, ok := headersInterface.(map[string]interface{}); !ok {
 			return fmt.Errorf("momorph headers is not a valid object")
 		}
 	}
 
-	// Update the GitHub token field
-	headers["x-github-token"] = githubToken
-
-	// Update the URL field with the MCP server endpoint
-	momorphServer["url"] = mcpServerEndpoint
-
-	// Marshal back to JSON with indentation
-	updatedData, err := json.MarshalIndent(mcpConfig, "", "  ")
+	// Update the GitHub token and the URL in place, preserving all other
+	// fields, comments and formatting
+	data, err = setJSONCMember(data, []string{"mcpServers", "momorph", "headers", "x-github-token"}, githubToken)
 	if err != nil {
-		return fmt.Errorf("failed to marshal .mcp.json: %w", err)
+		return fmt.Errorf("failed to update .mcp.json: %w", err)
+	}
+	data, err = setJSONCMember(data, []string{"mcpServers", "momorph", "url"}, mcpServerEndpoint)
+	if err != nil {
+		return fmt.Errorf("failed to update .mcp.json: %w", err)
 	}
 
-	// Write back to file
-	if err := os.WriteFile(mcpFilePath, updatedData, 0644); err != nil {
+	// Write back to file. No backup: .mcp.json belongs to the project, where
+	// a .bak holding the token could end up committed, and version control
+	// already keeps its previous content.
+	if err := writeMCPConfig(mcpFilePath, data, false); err != nil {
 		return fmt.Errorf("failed to write .mcp.json: %w", err)
 	}
 
@@ -113,6 +115,11 @@ func (c *claudeConfigUpdater) ConfigureMCPServer(projectDir, githubToken, mcpSer
 // copilotConfigUpdater handles Copilot-specific config updates (placeholder for future)
 type copilotConfigUpdater struct{}
 
+// ConfigPath returns "": Copilot gets its MCP servers from the VSCode extension
+func (c *copilotConfigUpdater) ConfigPath(projectDir string) (string, error) {
+	return "", nil
+}
+
 // ConfigureMCPServer updates Copilot config (not implemented yet)
 func (c *copilotConfigUpdater) ConfigureMCPServer(projectDir, githubToken, mcpServerEndpoint string) error {
 	logger.Debug("MCP servers are integrated via MoMorph VSCode Extension, skipping Copilot config update")
@@ -122,17 +129,30 @@ func (c *copilotConfigUpdater) ConfigureMCPServer(projectDir, githubToken, mcpSe
 // cursorConfigUpdater handles Cursor-specific config updates
 type cursorConfigUpdater struct{}
 
+// ConfigPath returns Cursor's global ~/.cursor/mcp.json
+func (c *cursorConfigUpdater) ConfigPath(projectDir string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".cursor", "mcp.json"), nil
+}
+
 // ConfigureMCPServer updates Cursor's global mcp.json with MoMorph server
 // Config file: ~/.cursor/mcp.json
 func (c *cursorConfigUpdater) ConfigureMCPServer(projectDir, githubToken, mcpServerEndpoint string) error {
 	// Cursor config is in user's home directory, not project directory
-	homeDir, err := os.UserHomeDir()
+	mcpFilePath, err := c.ConfigPath(projectDir)
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
+	cursorDir := filepath.Dir(mcpFilePath)
 
-	cursorDir := filepath.Join(homeDir, ".cursor")
-	mcpFilePath := filepath.Join(cursorDir, "mcp.json")
+	// Don't create ~/.cursor for users who don't have Cursor
+	if !editorInstalled(cursorDir, "cursor") {
+		logger.Debug("Cursor not found (no %s, no cursor on PATH), skipping MCP config", cursorDir)
+		return nil
+	}
 
 	// Ensure .cursor directory exists
 	if err := os.MkdirAll(cursorDir, 0755); err != nil {
@@ -140,42 +160,24 @@ func (c *cursorConfigUpdater) ConfigureMCPServer(projectDir, githubToken, mcpSer
 	}
 
 	// Read existing config or create new one
-	var mcpConfig map[string]interface{}
-	if data, err := os.ReadFile(mcpFilePath); err == nil {
-		if err := json.Unmarshal(data, &mcpConfig); err != nil {
-			// If parsing fails, start fresh but log warning
-			logger.Warn("Failed to parse existing Cursor mcp.json, creating new: %v", err)
-			mcpConfig = make(map[string]interface{})
-		}
-	} else {
-		mcpConfig = make(map[string]interface{})
-	}
-
-	// Get or create mcpServers
-	var servers map[string]interface{}
-	if serversInterface, exists := mcpConfig["mcpServers"]; exists {
-		servers, _ = serversInterface.(map[string]interface{})
-	}
-	if servers == nil {
-		servers = make(map[string]interface{})
-		mcpConfig["mcpServers"] = servers
+	data, _, err := readMCPConfig(mcpFilePath, true)
+	if err != nil {
+		return fmt.Errorf("failed to read Cursor mcp.json: %w", err)
 	}
 
-	// Add/update momorph server configuration
-	servers["momorph"] = map[string]interface{}{
+	// Add/update momorph server configuration, creating mcpServers if needed
+	data, err = setJSONCMember(data, []string{"mcpServers", "momorph"}, map[string]interface{}{
 		"url": mcpServerEndpoint,
 		"headers": map[string]string{
 			"x-github-token": githubToken,
 		},
-	}
-
-	// Write back to file
-	updatedData, err := json.MarshalIndent(mcpConfig, "", "  ")
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal Cursor mcp.json: %w", err)
+		return fmt.Errorf("failed to update Cursor mcp.json: %w", err)
 	}
 
-	if err := os.WriteFile(mcpFilePath, updatedData, 0644); err != nil {
+	// Write back to file
+	if err := writeMCPConfig(mcpFilePath, data, true); err != nil {
 		return fmt.Errorf("failed to write Cursor mcp.json: %w", err)
 	}
 
@@ -186,17 +188,30 @@ func (c *cursorConfigUpdater) ConfigureMCPServer(projectDir, githubToken, mcpSer
 // windsurfConfigUpdater handles Windsurf-specific config updates
 type windsurfConfigUpdater struct{}
 
+// ConfigPath returns Windsurf's global ~/.codeium/windsurf/mcp_config.json
+func (w *windsurfConfigUpdater) ConfigPath(projectDir string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".codeium", "windsurf", "mcp_config.json"), nil
+}
+
 // ConfigureMCPServer updates Windsurf's global mcp_config.json with MoMorph server
 // Config file: ~/.codeium/windsurf/mcp_config.json
 func (w *windsurfConfigUpdater) ConfigureMCPServer(projectDir, githubToken, mcpServerEndpoint string) error {
 	// Windsurf config is in user's home directory
-	homeDir, err := os.UserHomeDir()
+	mcpFilePath, err := w.ConfigPath(projectDir)
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
+	windsurfDir := filepath.Dir(mcpFilePath)
 
-	windsurfDir := filepath.Join(homeDir, ".codeium", "windsurf")
-	mcpFilePath := filepath.Join(windsurfDir, "mcp_config.json")
+	// Don't create ~/.codeium/windsurf for users who don't have Windsurf
+	if !editorInstalled(windsurfDir, "windsurf") {
+		logger.Debug("Windsurf not found (no %s, no windsurf on PATH), skipping MCP config", windsurfDir)
+		return nil
+	}
 
 	// Ensure directory exists
 	if err := os.MkdirAll(windsurfDir, 0755); err != nil {
@@ -204,49 +219,252 @@ func (w *windsurfConfigUpdater) ConfigureMCPServer(projectDir, githubToken, mcpS
 	}
 
 	// Read existing config or create new one
-	var mcpConfig map[string]interface{}
-	if data, err := os.ReadFile(mcpFilePath); err == nil {
-		if err := json.Unmarshal(data, &mcpConfig); err != nil {
-			logger.Warn("Failed to parse existing Windsurf mcp_config.json, creating new: %v", err)
-			mcpConfig = make(map[string]interface{})
-		}
-	} else {
-		mcpConfig = make(map[string]interface{})
-	}
-
-	// Get or create mcpServers
-	var servers map[string]interface{}
-	if serversInterface, exists := mcpConfig["mcpServers"]; exists {
-		servers, _ = serversInterface.(map[string]interface{})
-	}
-	if servers == nil {
-		servers = make(map[string]interface{})
-		mcpConfig["mcpServers"] = servers
+	data, _, err := readMCPConfig(mcpFilePath, true)
+	if err != nil {
+		return fmt.Errorf("failed to read Windsurf mcp_config.json: %w", err)
 	}
 
-	// Add/update momorph server configuration
+	// Add/update momorph server configuration, creating mcpServers if needed
 	// Windsurf uses "serverUrl" instead of "url"
-	servers["momorph"] = map[string]interface{}{
+	data, err = setJSONCMember(data, []string{"mcpServers", "momorph"}, map[string]interface{}{
 		"serverUrl": mcpServerEndpoint,
 		"headers": map[string]string{
 			"x-github-token": githubToken,
 		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update Windsurf mcp_config.json: %w", err)
 	}
 
 	// Write back to file
-	updatedData, err := json.MarshalIndent(mcpConfig, "", "  ")
+	if err := writeMCPConfig(mcpFilePath, data, true); err != nil {
+		return fmt.Errorf("failed to write Windsurf mcp_config.json: %w", err)
+	}
+
+	logger.Info("Updated MoMorph config in Windsurf's mcp_config.json at %s", mcpFilePath)
+	return nil
+}
+
+// GlobalMCPConfigPaths returns the user-wide MCP config files init writes
+// the momorph server into (Cursor and Windsurf)
+func GlobalMCPConfigPaths() ([]string, error) {
+	var paths []string
+	for _, updater := range []ConfigUpdater{&cursorConfigUpdater{}, &windsurfConfigUpdater{}} {
+		path, err := updater.ConfigPath("")
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// HasMCPServer reports whether the MCP config at path contains the momorph
+// server. Missing or unparseable files don't.
+func HasMCPServer(path string) bool {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("failed to marshal Windsurf mcp_config.json: %w", err)
+		return false
+	}
+	var mcpConfig map[string]interface{}
+	if err := parseJSONC(data, &mcpConfig); err != nil {
+		return false
+	}
+	servers, ok := mcpConfig["mcpServers"].(map[string]interface{})
+	if !ok {
+		return false
 	}
+	_, exists := servers["momorph"]
+	return exists
+}
 
-	if err := os.WriteFile(mcpFilePath, updatedData, 0644); err != nil {
-		return fmt.Errorf("failed to write Windsurf mcp_config.json: %w", err)
+// RemoveMCPServer removes the momorph server, and with it the stored GitHub
+// token, from the MCP config at path, leaving other servers intact. It
+// reports whether the file contained the server; a missing file is not an
+// error.
+func RemoveMCPServer(path string) (bool, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return false, nil
 	}
 
-	logger.Info("Updated MoMorph config in Windsurf's mcp_config.json at %s", mcpFilePath)
+	data, mcpConfig, err := readMCPConfig(path, false)
+	if err != nil {
+		return false, err
+	}
+	servers, ok := mcpConfig["mcpServers"].(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	if _, exists := servers["momorph"]; !exists {
+		return false, nil
+	}
+
+	// No backup: it would keep the token this is meant to remove
+	data, err = removeJSONCMember(data, []string{"mcpServers", "momorph"})
+	if err != nil {
+		return false, err
+	}
+	if err := writeMCPConfig(path, data, false); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	logger.Info("Removed momorph MCP server from %s", path)
+	return true, nil
+}
+
+// mcpBackupSuffixPattern matches what follows "<config>." in the name of a
+// timestamped backup written by backupMCPConfig
+var mcpBackupSuffixPattern = regexp.MustCompile(`^\d{8}-\d{6}(-\d+)?\.bak$`)
+
+// mcpConfigBackups returns the backups of the MCP config at path: path.bak
+// and the timestamped path.<time>.bak files
+func mcpConfigBackups(path string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	base := filepath.Base(path)
+	var backups []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, base+".") {
+			continue
+		}
+		if suffix := strings.TrimPrefix(name, base+"."); suffix == "bak" || mcpBackupSuffixPattern.MatchString(suffix) {
+			backups = append(backups, filepath.Join(filepath.Dir(path), name))
+		}
+	}
+	return backups, nil
+}
+
+// RemoveMCPServerBackups deletes the backups of the MCP config at path that
+// hold a GitHub token, and returns the paths it deleted. Backups without a
+// token are kept. A backup is checked for the header name rather than
+// parsed, since backups are also made of files that could not be parsed.
+func RemoveMCPServerBackups(path string) ([]string, error) {
+	backups, err := mcpConfigBackups(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for _, backup := range backups {
+		data, err := os.ReadFile(backup)
+		if err != nil {
+			return removed, err
+		}
+		if !bytes.Contains(data, []byte("x-github-token")) {
+			continue
+		}
+		if err := os.Remove(backup); err != nil {
+			return removed, err
+		}
+		logger.Info("Removed MCP config backup %s", backup)
+		removed = append(removed, backup)
+	}
+	return removed, nil
+}
+
+// readMCPConfig reads a JSON or JSONC (commented) MCP config and returns
+// its content along with its values as a generic map; a missing file yields
+// an empty object. With startFresh set, a file that cannot be parsed is
+// backed up and replaced by an empty object instead of failing, so it is
+// never discarded without a copy.
+func readMCPConfig(path string, startFresh bool) ([]byte, map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []byte("{}\n"), make(map[string]interface{}), nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var mcpConfig map[string]interface{}
+	if err := parseJSONC(data, &mcpConfig); err != nil {
+		if !startFresh {
+			return nil, nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		backupPath, backupErr := backupMCPConfig(path, data)
+		if backupErr != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s (%v) and could not back it up: %w", path, err, backupErr)
+		}
+		logger.Warn("Failed to parse %s, backed up to %s and creating new: %v", path, backupPath, err)
+		fmt.Fprintf(os.Stderr, "⚠ Could not parse %s; saved a copy to %s and wrote a new one\n", path, backupPath)
+		return []byte("{}\n"), make(map[string]interface{}), nil
+	}
+	if mcpConfig == nil {
+		// The file holds null
+		return []byte("{}\n"), make(map[string]interface{}), nil
+	}
+	return data, mcpConfig, nil
+}
+
+// writeMCPConfig writes the edited content of an MCP config to path. The
+// content is written to a temporary file and renamed over path, so a failed
+// write leaves the original intact. With backup set, the previous content
+// is kept in path+".bak". Backups are only made of the global configs in the
+// home directory, never of project files.
+func writeMCPConfig(path string, data []byte, backup bool) error {
+	if backup {
+		previous, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err == nil {
+			if err := os.WriteFile(path+".bak", previous, 0600); err != nil {
+				return fmt.Errorf("failed to back up %s: %w", path, err)
+			}
+		}
+	}
+
+	// Write to temporary file first (atomic write pattern)
+	tempFile := path + ".tmp"
+	if err := os.WriteFile(tempFile, data, 0644); err != nil {
+		os.Remove(tempFile)
+		return err
+	}
+	if err := os.Rename(tempFile, path); err != nil {
+		os.Remove(tempFile) // Clean up temp file on error
+		return err
+	}
 	return nil
 }
 
+// backupMCPConfig writes data to a new timestamped backup next to path,
+// never replacing an earlier one, and returns the backup's path
+func backupMCPConfig(path string, data []byte) (string, error) {
+	base := fmt.Sprintf("%s.%s", path, time.Now().Format("20060102-150405"))
+	backupPath := base + ".bak"
+	for i := 1; ; i++ {
+		f, err := os.OpenFile(backupPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if os.IsExist(err) {
+			backupPath = fmt.Sprintf("%s-%d.bak", base, i)
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if _, err := f.Write(data); err != nil {
+			f.Close()
+			return "", err
+		}
+		return backupPath, f.Close()
+	}
+}
+
+// editorInstalled reports whether an editor is present: its config
+// directory exists or its command is on PATH
+func editorInstalled(configDir, command string) bool {
+	if info, err := os.Stat(configDir); err == nil && info.IsDir() {
+		return true
+	}
+	_, err := exec.LookPath(command)
+	return err == nil
+}
+
 // GetConfigUpdater returns the appropriate config updater for the given AI tool
 func GetConfigUpdater(aiTool string) ConfigUpdater {
 	switch aiTool {
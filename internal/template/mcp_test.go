@@ -0,0 +1,145 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestRemoveMCPServerAndBackups(t *testing.T) {
+	dir := t.TempDir()
+	withToken := `{"mcpServers": {"momorph": {"url": "https://mcp", "headers": {"x-github-token": "gho_secret"}}, "other": {"url": "https://other"}}}`
+	withoutToken := `{"mcpServers": {"other": {"url": "https://other"}}}`
+	writeTestFiles(t, dir, map[string]string{
+		"mcp.json":                       withToken,
+		"mcp.json.bak":                   withToken,
+		"mcp.json.20260101-120000.bak":   "// commented\n" + withToken,
+		"mcp.json.20260101-120000-1.bak": "{ not json, but \"x-github-token\": \"gho_secret\"",
+		"mcp.json.20260102-090000.bak":   withoutToken,
+		"mcp.json.old":                   withToken,
+		"other.json.bak":                 withToken,
+		"mcp.json.notatimestamp.bak":     withToken,
+	})
+	path := filepath.Join(dir, "mcp.json")
+
+	ok, err := RemoveMCPServer(path)
+	if err != nil || !ok {
+		t.Fatalf("RemoveMCPServer = %v, %v; want true, nil", ok, err)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "gho_secret") || !strings.Contains(string(data), `"other"`) {
+		t.Errorf("config after removal = %s, want other server only", data)
+	}
+
+	removed, err := RemoveMCPServerBackups(path)
+	if err != nil {
+		t.Fatalf("RemoveMCPServerBackups: %v", err)
+	}
+	var names []string
+	for _, p := range removed {
+		names = append(names, filepath.Base(p))
+	}
+	sort.Strings(names)
+	want := []string{"mcp.json.20260101-120000-1.bak", "mcp.json.20260101-120000.bak", "mcp.json.bak"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("removed %v, want %v", names, want)
+	}
+
+	// Backups without a token and files that are not backups of path stay
+	for _, name := range []string{"mcp.json", "mcp.json.20260102-090000.bak", "mcp.json.old", "other.json.bak", "mcp.json.notatimestamp.bak"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s was removed: %v", name, err)
+		}
+	}
+}
+
+func TestRemoveMCPServerBackupsMissingDir(t *testing.T) {
+	removed, err := RemoveMCPServerBackups(filepath.Join(t.TempDir(), "missing", "mcp.json"))
+	if err != nil || len(removed) != 0 {
+		t.Errorf("RemoveMCPServerBackups = %v, %v; want nothing", removed, err)
+	}
+}
+
+func TestConfigureMCPServerBackups(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	config := `{"mcpServers": {"momorph": {"url": "https://old", "headers": {"x-github-token": "gho_old"}}}}`
+
+	// Project config: updated without leaving a backup in the project
+	project := t.TempDir()
+	writeTestFiles(t, project, map[string]string{".mcp.json": config})
+	if err := UpdateAIToolConfig("claude", project, "gho_new", "https://mcp"); err != nil {
+		t.Fatalf("claude: %v", err)
+	}
+	entries, _ := os.ReadDir(project)
+	for _, entry := range entries {
+		if entry.Name() != ".mcp.json" {
+			t.Errorf("project directory has %s, want only .mcp.json", entry.Name())
+		}
+	}
+
+	// Global config: the previous content is kept next to it
+	writeTestFiles(t, home, map[string]string{".cursor/mcp.json": config})
+	if err := UpdateAIToolConfig("cursor", project, "gho_new", "https://mcp"); err != nil {
+		t.Fatalf("cursor: %v", err)
+	}
+	backup, err := os.ReadFile(filepath.Join(home, ".cursor", "mcp.json.bak"))
+	if err != nil || string(backup) != config {
+		t.Errorf("cursor backup = %q, %v; want the previous config", backup, err)
+	}
+}
+
+func TestConfigureMCPServerKeepsComments(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	project := t.TempDir()
+	writeTestFiles(t, project, map[string]string{".mcp.json": "{\n" +
+		"  // project servers\n" +
+		"  \"mcpServers\": {\n" +
+		"    \"momorph\": {\n" +
+		"      \"type\": \"http\",\n" +
+		"      \"url\": \"https://old\", // set by init\n" +
+		"      \"headers\": {\"x-github-token\": \"gho_old\"}\n" +
+		"    }\n" +
+		"  }\n" +
+		"}\n"})
+	if err := UpdateAIToolConfig("claude", project, "gho_new", "https://mcp"); err != nil {
+		t.Fatalf("claude: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(project, ".mcp.json"))
+	want := "{\n" +
+		"  // project servers\n" +
+		"  \"mcpServers\": {\n" +
+		"    \"momorph\": {\n" +
+		"      \"type\": \"http\",\n" +
+		"      \"url\": \"https://mcp\", // set by init\n" +
+		"      \"headers\": {\"x-github-token\": \"gho_new\"}\n" +
+		"    }\n" +
+		"  }\n" +
+		"}\n"
+	if string(data) != want {
+		t.Errorf(".mcp.json =\n%s\nwant\n%s", data, want)
+	}
+
+	writeTestFiles(t, home, map[string]string{".cursor/mcp.json": "{\n" +
+		"  // my servers\n" +
+		"  \"mcpServers\": {\n" +
+		"    \"other\": {\"url\": \"https://other\"} // keep\n" +
+		"  }\n" +
+		"}\n"})
+	if err := UpdateAIToolConfig("cursor", project, "gho_new", "https://mcp"); err != nil {
+		t.Fatalf("cursor: %v", err)
+	}
+	data, _ = os.ReadFile(filepath.Join(home, ".cursor", "mcp.json"))
+	if !strings.Contains(string(data), "// my servers") || !strings.Contains(string(data), "// keep") {
+		t.Errorf("cursor mcp.json lost its comments:\n%s", data)
+	}
+	if !HasMCPServer(filepath.Join(home, ".cursor", "mcp.json")) || !strings.Contains(string(data), "gho_new") {
+		t.Errorf("cursor mcp.json has no momorph server:\n%s", data)
+	}
+}
@@ -2,7 +2,6 @@ package template
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -32,7 +31,10 @@ func ShouldMerge(relativePath string) (MergeType, bool) {
 }
 
 // MergeJSONFiles performs a deep merge of template JSON into existing JSON file
-// Template values are merged into existing values using deep merge strategy
+// Keys missing from the existing file are added, nested objects are merged
+// recursively, and existing values take precedence. The existing file is
+// edited in place, so its key order, formatting and comments (JSONC, as VS
+// Code allows in settings.json) are preserved.
 func MergeJSONFiles(existingPath, templatePath string) error {
 	// Read existing file
 	existingData, err := os.ReadFile(existingPath)
@@ -46,22 +48,9 @@ func MergeJSONFiles(existingPath, templatePath string) error {
 		return fmt.Errorf("failed to read template file: %w", err)
 	}
 
-	// Parse both as generic maps
-	var existing, template map[string]interface{}
-	if err := json.Unmarshal(existingData, &existing); err != nil {
-		return fmt.Errorf("failed to parse existing JSON: %w", err)
-	}
-	if err := json.Unmarshal(templateData, &template); err != nil {
-		return fmt.Errorf("failed to parse template JSON: %w", err)
-	}
-
-	// Deep merge template into existing
-	merged := deepMerge(existing, template)
-
-	// Write merged result with proper formatting
-	mergedData, err := json.MarshalIndent(merged, "", "  ")
+	mergedData, err := mergeJSONC(existingData, templateData)
 	if err != nil {
-		return fmt.Errorf("failed to marshal merged JSON: %w", err)
+		return err
 	}
 
 	if err := os.WriteFile(existingPath, mergedData, 0644); err != nil {
@@ -72,39 +61,57 @@ func MergeJSONFiles(existingPath, templatePath string) error {
 	return nil
 }
 
-// deepMerge recursively merges template map into existing map
-// - Keys only in existing are preserved
-// - Keys only in template are added
-// - Keys in both: if both are maps, merge recursively; otherwise keep existing value
-func deepMerge(existing, template map[string]interface{}) map[string]interface{} {
-	result := make(map[string]interface{})
-
-	// Copy all existing values first
-	for k, v := range existing {
-		result[k] = v
+// mergeJSONC merges the template document into the existing one, both JSON
+// or JSONC objects, and returns the edited existing document
+func mergeJSONC(existingData, templateData []byte) ([]byte, error) {
+	existing, err := parseJSONDocument(existingData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse existing JSON: %w", err)
 	}
+	template, err := parseJSONDocument(templateData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template JSON: %w", err)
+	}
+	if !existing.isObject || !template.isObject {
+		return nil, fmt.Errorf("failed to merge JSON: both files must contain an object")
+	}
+
+	templateClean := stripJSONC(templateData)
+	edits := deepMergeEdits(existingData, existing, template, templateClean, indentUnit(existingData, existing))
+	return applyJSONEdits(existingData, edits), nil
+}
 
-	// Merge template values
-	for k, templateVal := range template {
-		existingVal, exists := result[k]
+// deepMergeEdits returns the edits that merge the template object into the
+// existing object
+// - Keys only in existing are preserved
+// - Keys only in template are added, in template order
+// - Keys in both: if both are objects, merge recursively; otherwise keep existing value
+func deepMergeEdits(existingData []byte, existing, template *jsonNode, templateClean []byte, unit string) []jsonEdit {
+	var edits []jsonEdit
+	var newKeys []string
+	var newValues [][]byte
+
+	for _, k := range template.keys {
+		templateVal := template.fields[k]
+		existingVal, exists := existing.fields[k]
 		if !exists {
 			// Key doesn't exist in existing, add template value
-			result[k] = templateVal
+			newKeys = append(newKeys, k)
+			newValues = append(newValues, templateClean[templateVal.start:templateVal.end])
 			continue
 		}
 
-		// Both exist, check if both are maps for recursive merge
-		existingMap, existingIsMap := existingVal.(map[string]interface{})
-		templateMap, templateIsMap := templateVal.(map[string]interface{})
-
-		if existingIsMap && templateIsMap {
+		if existingVal.isObject && templateVal.isObject {
 			// Recursive merge for nested objects
-			result[k] = deepMerge(existingMap, templateMap)
+			edits = append(edits, deepMergeEdits(existingData, existingVal, templateVal, templateClean, unit)...)
 		}
 		// Otherwise, keep existing value (existing takes precedence)
 	}
 
-	return result
+	if len(newKeys) > 0 {
+		edits = append(edits, addMembersEdits(existingData, existing, newKeys, newValues, unit)...)
+	}
+	return edits
 }
 
 // MergeGitignoreFiles appends unique lines from template .gitignore to existing .gitignore
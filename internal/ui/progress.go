@@ -3,52 +3,168 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
-// ProgressBar represents a simple progress bar
+// spinnerFrames are shown in place of the bar when the total size is unknown
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const (
+	// renderInterval limits how often the bar is redrawn
+	renderInterval = 100 * time.Millisecond
+	// lineInterval limits how often progress lines are printed when stdout
+	// is not a terminal
+	lineInterval = 5 * time.Second
+	// rateSmoothing is the weight of the newest sample in the moving average
+	rateSmoothing = 0.3
+)
+
+// ProgressBar represents a simple progress bar. When the total is unknown
+// (<= 0) it shows a spinner with the bytes transferred instead of a bar.
+// When stdout is not a terminal it prints a plain progress line every few
+// seconds instead, so redirected output and CI logs stay readable.
 type ProgressBar struct {
-	total   int64
-	current int64
-	width   int
+	total       int64
+	current     int64
+	width       int
+	interactive bool
+
+	frame      int
+	rate       float64 // smoothed bytes per second
+	lastBytes  int64
+	lastSample time.Time
+	lastRender time.Time
 }
 
 // NewProgressBar creates a new progress bar
 func NewProgressBar(total int64) *ProgressBar {
 	return &ProgressBar{
-		total: total,
-		width: 40,
+		total:       total,
+		width:       40,
+		interactive: IsStdoutTerminal(),
+		lastSample:  time.Now(),
 	}
 }
 
 // Update updates the progress bar
 func (pb *ProgressBar) Update(current int64) {
 	pb.current = current
+	pb.sampleRate(time.Now())
+
+	interval := renderInterval
+	if !pb.interactive {
+		interval = lineInterval
+	}
+	if time.Since(pb.lastRender) < interval {
+		return
+	}
 	pb.Render()
 }
 
+// sampleRate folds the bytes received since the last sample into the
+// exponential moving average of the transfer rate
+func (pb *ProgressBar) sampleRate(now time.Time) {
+	elapsed := now.Sub(pb.lastSample).Seconds()
+	if elapsed < renderInterval.Seconds() {
+		return
+	}
+
+	instant := float64(pb.current-pb.lastBytes) / elapsed
+	if pb.rate == 0 {
+		pb.rate = instant
+	} else {
+		pb.rate = rateSmoothing*instant + (1-rateSmoothing)*pb.rate
+	}
+	pb.lastBytes = pb.current
+	pb.lastSample = now
+}
+
 // Render renders the progress bar
 func (pb *ProgressBar) Render() {
+	pb.lastRender = time.Now()
+
+	if !pb.interactive {
+		pb.renderLine()
+		return
+	}
+
 	if pb.total <= 0 {
+		pb.frame = (pb.frame + 1) % len(spinnerFrames)
+		fmt.Printf("\r%s %s%s\033[K",
+			spinnerFrames[pb.frame],
+			formatBytes(pb.current),
+			pb.rateSuffix())
 		return
 	}
 
-	percent := float64(pb.current) / float64(pb.total) * 100
-	filled := int(float64(pb.width) * float64(pb.current) / float64(pb.total))
+	current := pb.current
+	if current > pb.total {
+		current = pb.total
+	}
+	percent := float64(current) / float64(pb.total) * 100
+	filled := int(float64(pb.width) * float64(current) / float64(pb.total))
 
 	bar := strings.Repeat("█", filled) + strings.Repeat("░", pb.width-filled)
-	
-	fmt.Printf("\r[%s] %.1f%% (%s / %s)", 
-		bar, 
+
+	eta := ""
+	if pb.rate > 0 && current < pb.total {
+		remaining := time.Duration(float64(pb.total-current) / pb.rate * float64(time.Second))
+		eta = ", ETA " + formatETA(remaining)
+	}
+
+	fmt.Printf("\r[%s] %.1f%% (%s / %s%s%s)\033[K",
+		bar,
 		percent,
-		formatBytes(pb.current),
-		formatBytes(pb.total))
+		formatBytes(current),
+		formatBytes(pb.total),
+		pb.rateSuffix(),
+		eta)
+}
+
+// renderLine prints progress as a plain line, without carriage returns or
+// escape sequences
+func (pb *ProgressBar) renderLine() {
+	if pb.total <= 0 {
+		fmt.Printf("Downloaded %s%s\n", formatBytes(pb.current), pb.rateSuffix())
+		return
+	}
+	current := pb.current
+	if current > pb.total {
+		current = pb.total
+	}
+	fmt.Printf("Downloaded %s / %s (%.0f%%)%s\n",
+		formatBytes(current),
+		formatBytes(pb.total),
+		float64(current)/float64(pb.total)*100,
+		pb.rateSuffix())
+}
+
+// rateSuffix returns the smoothed transfer rate, or "" before the first sample
+func (pb *ProgressBar) rateSuffix() string {
+	if pb.rate <= 0 {
+		return ""
+	}
+	return ", " + formatBytes(int64(pb.rate)) + "/s"
 }
 
 // Finish completes the progress bar
 func (pb *ProgressBar) Finish() {
-	pb.current = pb.total
+	if pb.total > 0 {
+		pb.current = pb.total
+	}
 	pb.Render()
-	fmt.Println() // New line
+	if pb.interactive {
+		fmt.Println() // New line
+	}
+}
+
+// formatETA formats a remaining duration as m:ss, or h:mm:ss for long transfers
+func formatETA(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds%3600/60, seconds%60)
+	}
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
 }
 
 // formatBytes formats bytes to human-readable format
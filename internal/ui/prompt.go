@@ -70,3 +70,19 @@ func ConfirmUpdate(currentVersion, newVersion string) (bool, error) {
 	// Default to yes (empty input or "y"/"yes")
 	return input == "y" || input == "yes", nil
 }
+
+// ConfirmDowngrade prompts the user to confirm installing an older version
+func ConfirmDowngrade(currentVersion, targetVersion string) (bool, error) {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Printf("⚠  %s is older than the installed version %s\n", targetVersion, currentVersion)
+	fmt.Print("Do you want to downgrade? (y/N): ")
+
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return false, err
+	}
+
+	input = strings.TrimSpace(strings.ToLower(input))
+	return input == "y" || input == "yes", nil
+}
@@ -1,10 +1,31 @@
 package ui
 
 import (
+	"os"
 	"path/filepath"
 	"strings"
 )
 
+// IsInteractive reports whether stdin is a terminal, i.e. whether prompts
+// can be answered. It is false in CI, pipes and redirected input.
+func IsInteractive() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// IsStdoutTerminal reports whether stdout is a terminal. It is false when
+// output is redirected to a file or captured by CI.
+func IsStdoutTerminal() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
 // ShortenPath shortens a path by abbreviating parent directories
 // e.g., /Users/john/workspaces/project -> /U/j/w/project
 func ShortenPath(path string) string {
@@ -3,6 +3,7 @@ package update
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"runtime"
@@ -18,7 +19,8 @@ const (
 	repoName  = "cli"
 
 	// GitHub API endpoints
-	releasesAPI = "https://api.github.com/repos/%s/%s/releases/latest"
+	releasesAPI   = "https://api.github.com/repos/%s/%s/releases/latest"
+	releaseTagAPI = "https://api.github.com/repos/%s/%s/releases/tags/%s"
 )
 
 // Release represents a GitHub release
@@ -37,12 +39,31 @@ type Asset struct {
 	BrowserDownloadURL string `json:"browser_download_url"`
 	Size               int64  `json:"size"`
 	ContentType        string `json:"content_type"`
+	// SHA256 is the expected checksum from the release's checksums file,
+	// filled in by Release.ResolveChecksum
+	SHA256 string `json:"-"`
 }
 
+// ErrNoReleases is returned by GetLatestRelease when nothing has been
+// published yet
+var ErrNoReleases = errors.New("no releases found")
+
 // GetLatestRelease fetches the latest release from GitHub
 func GetLatestRelease(ctx context.Context) (*Release, error) {
-	url := fmt.Sprintf(releasesAPI, repoOwner, repoName)
+	return fetchRelease(ctx, fmt.Sprintf(releasesAPI, repoOwner, repoName), ErrNoReleases)
+}
 
+// GetReleaseByTag fetches a specific release from GitHub. The version may be
+// given with or without the "v" prefix (e.g. "1.2.3" or "v1.2.3").
+func GetReleaseByTag(ctx context.Context, version string) (*Release, error) {
+	tag := "v" + strings.TrimPrefix(strings.TrimSpace(version), "v")
+	url := fmt.Sprintf(releaseTagAPI, repoOwner, repoName, tag)
+	return fetchRelease(ctx, url, fmt.Errorf("release %s not found", tag))
+}
+
+// fetchRelease fetches a single release from the GitHub API, returning
+// notFound as the error on 404
+func fetchRelease(ctx context.Context, url string, notFound error) (*Release, error) {
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -63,7 +84,7 @@ func GetLatestRelease(ctx context.Context) (*Release, error) {
 
 	// Check status
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("no releases found")
+		return nil, notFound
 	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("GitHub API error (status %d)", resp.StatusCode)
@@ -121,6 +142,72 @@ func (r *Release) GetAssetForPlatform() (*Asset, error) {
 	return nil, fmt.Errorf("no release asset found for %s/%s", os, arch)
 }
 
+// GetChecksumsAsset returns the checksums file published with the release
+// (e.g. "checksums.txt" or "momorph-cli_1.2.3_checksums.txt"), or nil if none
+func (r *Release) GetChecksumsAsset() *Asset {
+	for i := range r.Assets {
+		name := strings.ToLower(r.Assets[i].Name)
+		if name == "checksums.txt" || strings.HasSuffix(name, "_checksums.txt") {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// ResolveChecksum downloads the release's checksums file and sets
+// asset.SHA256 to the expected checksum of asset
+func (r *Release) ResolveChecksum(ctx context.Context, asset *Asset) error {
+	checksumsAsset := r.GetChecksumsAsset()
+	if checksumsAsset == nil {
+		return fmt.Errorf("release %s has no checksums file", r.TagName)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", checksumsAsset.BrowserDownloadURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	client := utils.NewHTTPClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch checksums: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch checksums (status %d)", resp.StatusCode)
+	}
+
+	body, err := utils.ReadResponseBody(resp, 1024*1024)
+	if err != nil {
+		return err
+	}
+
+	checksum, ok := parseChecksums(string(body))[asset.Name]
+	if !ok {
+		return fmt.Errorf("no checksum listed for %s", asset.Name)
+	}
+
+	asset.SHA256 = checksum
+	return nil
+}
+
+// parseChecksums parses "sha256sum" output ("<hex>  <filename>" per line)
+// into a map of filename to lowercase checksum
+func parseChecksums(content string) map[string]string {
+	checksums := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		// A leading "*" marks binary mode in sha256sum output
+		name := strings.TrimPrefix(fields[1], "*")
+		checksums[name] = strings.ToLower(fields[0])
+	}
+	return checksums
+}
+
 // CompareVersions compares two semver versions
 // Returns: -1 if v1 < v2, 0 if v1 == v2, 1 if v1 > v2
 func CompareVersions(v1, v2 string) int {
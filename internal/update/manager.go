@@ -0,0 +1,105 @@
+package update
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/momorph/cli/internal/logger"
+)
+
+// PackageManager describes a package manager that owns the installed binary
+type PackageManager struct {
+	// Name is the package manager, e.g. "Homebrew"
+	Name string
+	// UpgradeCommand updates the CLI through the package manager
+	UpgradeCommand string
+}
+
+// DetectPackageManager reports which package manager installed the running
+// binary, or nil if it was installed by hand (install script, go install or
+// a downloaded release). Replacing a managed binary in place would leave the
+// package manager's records out of sync with the files on disk.
+func DetectPackageManager() *PackageManager {
+	execPath, err := executablePath()
+	if err != nil {
+		logger.Debug("Cannot detect package manager: %v", err)
+		return nil
+	}
+	return detectPackageManager(execPath)
+}
+
+func detectPackageManager(execPath string) *PackageManager {
+	path := filepath.ToSlash(execPath)
+	lower := strings.ToLower(path)
+
+	switch {
+	case strings.Contains(path, "/Cellar/"):
+		return &PackageManager{Name: "Homebrew", UpgradeCommand: "brew upgrade " + pathSegmentAfter(path, "/Cellar/", "momorph-cli")}
+	case strings.Contains(lower, "/scoop/apps/"):
+		return &PackageManager{Name: "Scoop", UpgradeCommand: "scoop update " + pathSegmentAfter(lower, "/scoop/apps/", "momorph-cli")}
+	case strings.Contains(lower, "/chocolatey/lib/"):
+		return &PackageManager{Name: "Chocolatey", UpgradeCommand: "choco upgrade " + pathSegmentAfter(lower, "/chocolatey/lib/", "momorph-cli")}
+	case strings.HasPrefix(path, "/snap/"):
+		return &PackageManager{Name: "Snap", UpgradeCommand: "sudo snap refresh " + pathSegmentAfter(path, "/snap/", "momorph")}
+	}
+
+	// Binaries installed by system packages live under /usr, but not
+	// /usr/local, which is where install scripts put them
+	if runtime.GOOS != "linux" || !strings.HasPrefix(path, "/usr/") || strings.HasPrefix(path, "/usr/local/") {
+		return nil
+	}
+	if pkg := queryOwner("dpkg", "-S", execPath); pkg != "" {
+		return &PackageManager{Name: "apt", UpgradeCommand: "sudo apt install --only-upgrade " + pkg}
+	}
+	if pkg := queryOwner("rpm", "-qf", "--queryformat", "%{NAME}", execPath); pkg != "" {
+		return &PackageManager{Name: "rpm", UpgradeCommand: "sudo dnf upgrade " + pkg}
+	}
+	return nil
+}
+
+// pathSegmentAfter returns the path segment following marker in path, which
+// for package managers is the package name, or fallback if there is none
+func pathSegmentAfter(path, marker, fallback string) string {
+	i := strings.Index(path, marker)
+	if i < 0 {
+		return fallback
+	}
+	rest := path[i+len(marker):]
+	if j := strings.Index(rest, "/"); j > 0 {
+		return rest[:j]
+	}
+	return fallback
+}
+
+// queryOwner runs a package manager query for the package owning a file and
+// returns the package name, or "" if the tool is missing or no package owns it
+func queryOwner(name string, args ...string) string {
+	if _, err := exec.LookPath(name); err != nil {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, name, args...).Output()
+	if err != nil {
+		return ""
+	}
+
+	// dpkg -S prints "package[:arch]: /path"; rpm prints just the name
+	pkg := strings.TrimSpace(string(out))
+	if i := strings.Index(pkg, ": "); i >= 0 {
+		pkg = pkg[:i]
+	}
+	if i := strings.Index(pkg, ":"); i >= 0 {
+		pkg = pkg[:i]
+	}
+	if strings.ContainsAny(pkg, " \n") {
+		return ""
+	}
+	logger.Debug("%s reports %s as owned by %s", name, args[len(args)-1], pkg)
+	return pkg
+}
@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/momorph/cli/internal/logger"
 	"github.com/momorph/cli/internal/utils"
@@ -22,23 +23,22 @@ import (
 // ProgressCallback is called to report download progress
 type ProgressCallback func(downloaded, total int64)
 
-// DownloadAndReplace downloads a new binary and replaces the current one
+// DownloadAndReplace downloads a new binary and replaces the current one.
+// If asset.SHA256 is set, the download is verified against it first.
 // Returns the path of the installed binary on success
 func DownloadAndReplace(ctx context.Context, asset *Asset, progress ProgressCallback) (string, error) {
-	// Get the current executable path
-	execPath, err := os.Executable()
-	if err != nil {
-		return "", fmt.Errorf("failed to get executable path: %w", err)
-	}
-
-	// Resolve symlinks
-	execPath, err = filepath.EvalSymlinks(execPath)
+	execPath, err := executablePath()
 	if err != nil {
-		return "", fmt.Errorf("failed to resolve executable path: %w", err)
+		return "", err
 	}
 
 	logger.Debug("Current executable: %s", execPath)
 
+	// Fail before downloading anything if the binary cannot be replaced
+	if err := checkWritable(execPath); err != nil {
+		return "", err
+	}
+
 	// Create temporary directory for extraction
 	tempDir, err := os.MkdirTemp(filepath.Dir(execPath), "mm-update-*")
 	if err != nil {
@@ -60,6 +60,14 @@ func DownloadAndReplace(ctx context.Context, asset *Asset, progress ProgressCall
 	}
 	archiveFile.Close()
 
+	// Verify integrity before touching the installed binary
+	if asset.SHA256 != "" {
+		if err := VerifyChecksum(archivePath, asset.SHA256); err != nil {
+			return "", fmt.Errorf("downloaded file failed verification, current binary left unchanged: %w", err)
+		}
+		logger.Debug("Checksum verified for %s", asset.Name)
+	}
+
 	// Extract binary from archive
 	var binaryPath string
 	if strings.HasSuffix(asset.Name, ".tar.gz") || strings.HasSuffix(asset.Name, ".tgz") {
@@ -83,34 +91,151 @@ func DownloadAndReplace(ctx context.Context, asset *Asset, progress ProgressCall
 		}
 	}
 
-	// Backup current binary
-	backupPath := execPath + ".backup"
-	if err := os.Rename(execPath, backupPath); err != nil {
-		return "", fmt.Errorf("failed to backup current binary: %w", err)
+	if err := replaceBinary(binaryPath, execPath); err != nil {
+		return "", err
+	}
+
+	logger.Info("Binary updated successfully")
+	return execPath, nil
+}
+
+// CheckWritable reports whether the running binary can be replaced by
+// DownloadAndReplace, so the update can be refused before it is downloaded.
+// The error suggests how to update instead, e.g. through Homebrew.
+func CheckWritable() error {
+	execPath, err := executablePath()
+	if err != nil {
+		return err
+	}
+	return checkWritable(execPath)
+}
+
+// executablePath returns the path of the running binary with symlinks
+// resolved, e.g. the Homebrew Cellar path rather than the bin/ link
+func executablePath() (string, error) {
+	execPath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to get executable path: %w", err)
 	}
 
-	// Copy new binary to place (use copy instead of rename for cross-device moves)
-	if err := copyFile(binaryPath, execPath); err != nil {
-		// Try to restore backup
-		os.Rename(backupPath, execPath)
-		return "", fmt.Errorf("failed to replace binary: %w", err)
+	// Resolve symlinks
+	execPath, err = filepath.EvalSymlinks(execPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve executable path: %w", err)
+	}
+	return execPath, nil
+}
+
+// checkWritable probes the directory of execPath by creating and removing a
+// file in it, which is what replacing the binary needs. A root-owned install
+// or a read-only mount fails here instead of after the download.
+func checkWritable(execPath string) error {
+	dir := filepath.Dir(execPath)
+	probe, err := os.CreateTemp(dir, ".mm-write-check-*")
+	if err == nil {
+		probe.Close()
+		err = os.Remove(probe.Name())
+	}
+	if err == nil {
+		return nil
+	}
+
+	logger.Debug("Write probe in %s failed: %v", dir, err)
+	if pm := detectPackageManager(execPath); pm != nil {
+		return fmt.Errorf("%s is managed by %s and cannot be updated in place; run `%s` instead", execPath, pm.Name, pm.UpgradeCommand)
+	}
+	return fmt.Errorf("cannot replace %s, its directory is not writable (%v); update it with the package manager it was installed with, or re-run with permission to write there", execPath, err)
+}
+
+// backupSuffix is appended to the running binary while it is being replaced
+const backupSuffix = ".backup"
+
+// replaceBinary moves the running binary aside and puts newPath in its place.
+// Windows does not allow overwriting or deleting a running executable, but it
+// does allow renaming it, so the old binary is renamed to execPath+".backup"
+// and removed on the next start if it cannot be removed now (see
+// RemoveStaleBackup). On any failure the original binary is restored.
+func replaceBinary(newPath, execPath string) error {
+	backupPath := execPath + backupSuffix
+
+	// A backup left by an earlier update would block the rename on Windows
+	os.Remove(backupPath)
+
+	if err := renameWithRetry(execPath, backupPath); err != nil {
+		return fmt.Errorf("failed to move current binary aside: %w", err)
+	}
+
+	// Prefer an atomic rename (newPath is in the same directory), falling back to a copy
+	if err := renameWithRetry(newPath, execPath); err != nil {
+		logger.Debug("Rename of new binary failed, copying instead: %v", err)
+		if err := copyFile(newPath, execPath); err != nil {
+			return restoreBinary(backupPath, execPath, fmt.Errorf("failed to replace binary: %w", err))
+		}
 	}
 
 	// Set permissions on the final binary
 	if runtime.GOOS != "windows" {
 		if err := os.Chmod(execPath, 0755); err != nil {
-			// Try to restore backup
-			os.Remove(execPath)
-			os.Rename(backupPath, execPath)
-			return "", fmt.Errorf("failed to set permissions: %w", err)
+			return restoreBinary(backupPath, execPath, fmt.Errorf("failed to set permissions: %w", err))
 		}
 	}
 
-	// Remove backup
-	os.Remove(backupPath)
+	// Remove backup; on Windows this fails while the old binary is still running
+	if err := os.Remove(backupPath); err != nil {
+		logger.Debug("Previous binary will be removed on next start: %v", err)
+	}
 
-	logger.Info("Binary updated successfully")
-	return execPath, nil
+	return nil
+}
+
+// restoreBinary puts the backup back in place after a failed replacement and
+// returns cause, annotated with the outcome of the restore
+func restoreBinary(backupPath, execPath string, cause error) error {
+	os.Remove(execPath)
+	if err := renameWithRetry(backupPath, execPath); err != nil {
+		return fmt.Errorf("%w; restoring the previous binary also failed, it is saved at %s: %v", cause, backupPath, err)
+	}
+	return fmt.Errorf("%w; the previous binary was restored", cause)
+}
+
+// renameWithRetry renames a file, retrying briefly on failure. On Windows,
+// antivirus scanners and the indexer can hold a freshly written file open for
+// a moment, making the first attempt fail.
+func renameWithRetry(from, to string) error {
+	var err error
+	for attempt := 0; attempt < 5; attempt++ {
+		if err = os.Rename(from, to); err == nil {
+			return nil
+		}
+		if runtime.GOOS != "windows" {
+			return err
+		}
+		time.Sleep(time.Duration(attempt+1) * 100 * time.Millisecond)
+	}
+	return err
+}
+
+// RemoveStaleBackup deletes the previous binary left next to the executable
+// by a self-update that could not remove it (always the case on Windows).
+// Errors are ignored; the file is retried on the next start.
+func RemoveStaleBackup() {
+	execPath, err := os.Executable()
+	if err != nil {
+		return
+	}
+	if resolved, err := filepath.EvalSymlinks(execPath); err == nil {
+		execPath = resolved
+	}
+
+	backupPath := execPath + backupSuffix
+	if _, err := os.Stat(backupPath); err != nil {
+		return
+	}
+	if err := os.Remove(backupPath); err != nil {
+		logger.Debug("Failed to remove previous binary %s: %v", backupPath, err)
+		return
+	}
+	logger.Debug("Removed previous binary %s", backupPath)
 }
 
 // extractTarGz extracts a .tar.gz archive and returns the path to the momorph binary
@@ -299,7 +424,7 @@ func VerifyChecksum(filePath, expectedChecksum string) error {
 	}
 
 	actualChecksum := hex.EncodeToString(hasher.Sum(nil))
-	if actualChecksum != expectedChecksum {
+	if actualChecksum != strings.ToLower(expectedChecksum) {
 		return fmt.Errorf("checksum mismatch: expected %s, got %s", expectedChecksum, actualChecksum)
 	}
 
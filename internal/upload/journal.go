@@ -0,0 +1,147 @@
+package upload
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/momorph/cli/internal/config"
+)
+
+// Journal records the files of an upload batch that completed, so that an
+// interrupted run can be resumed without uploading them again. A file is only
+// treated as completed while its contents are unchanged.
+type Journal struct {
+	path string
+	// Completed maps the absolute file path to the sha256 of its contents
+	// at the time it was uploaded
+	Completed map[string]string `json:"completed"`
+}
+
+// JournalPath returns where the journal for an upload type ("specs" or
+// "testcases") run from dir is stored. Each working directory gets its own
+// journal so resuming in one project does not skip files of another.
+func JournalPath(uploadType, dir string) string {
+	sum := sha256.Sum256([]byte(dir))
+	name := fmt.Sprintf("upload-%s-%s.json", uploadType, hex.EncodeToString(sum[:8]))
+	return filepath.Join(config.GetCacheDir(), "journals", name)
+}
+
+// NewJournal returns an empty journal for an upload type run from the
+// current directory. It replaces the saved journal, if any, when the first
+// file is marked completed.
+func NewJournal(uploadType string) (*Journal, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+	return &Journal{
+		path:      JournalPath(uploadType, cwd),
+		Completed: make(map[string]string),
+	}, nil
+}
+
+// LoadJournal reads the journal for an upload type run from the current
+// directory. A missing journal yields an empty one.
+func LoadJournal(uploadType string) (*Journal, error) {
+	j, err := NewJournal(uploadType)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(j.path)
+	if os.IsNotExist(err) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload journal: %w", err)
+	}
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, fmt.Errorf("failed to parse upload journal %s: %w", j.path, err)
+	}
+	if j.Completed == nil {
+		j.Completed = make(map[string]string)
+	}
+
+	return j, nil
+}
+
+// Len returns the number of files recorded as completed
+func (j *Journal) Len() int {
+	return len(j.Completed)
+}
+
+// IsCompleted reports whether file was uploaded by an earlier run and has
+// not been modified since
+func (j *Journal) IsCompleted(file string) bool {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return false
+	}
+	recorded, ok := j.Completed[abs]
+	if !ok {
+		return false
+	}
+	sum, err := fileChecksum(abs)
+	return err == nil && sum == recorded
+}
+
+// MarkCompleted records file as uploaded and saves the journal immediately,
+// so the progress survives the process being killed
+func (j *Journal) MarkCompleted(file string) error {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return err
+	}
+	sum, err := fileChecksum(abs)
+	if err != nil {
+		return err
+	}
+	j.Completed[abs] = sum
+	return j.save()
+}
+
+// Remove deletes the journal file once the whole batch has been uploaded
+func (j *Journal) Remove() error {
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// save writes the journal via a temporary file and rename
+func (j *Journal) save() error {
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write upload journal: %w", err)
+	}
+	return os.Rename(tmp, j.path)
+}
+
+// fileChecksum returns the hex sha256 of a file's contents
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
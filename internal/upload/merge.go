@@ -0,0 +1,36 @@
+package upload
+
+// Test case upload modes
+const (
+	TestcaseModeReplace = "replace" // overwrite existing test cases with the CSV contents
+	TestcaseModeAppend  = "append"  // merge CSV test cases into the existing ones
+)
+
+// MergeTestCases merges incoming test cases into existing ones for append mode.
+// Test cases are matched by ID (the TC_ID column): an incoming test case with
+// the same ID as an existing one replaces it in place, all others are appended
+// in CSV order. Test cases without an ID are always appended.
+func MergeTestCases(existing, incoming []TestCase) []TestCase {
+	merged := make([]TestCase, len(existing))
+	copy(merged, existing)
+
+	index := make(map[string]int)
+	for i, tc := range merged {
+		if tc.ID != "" {
+			index[tc.ID] = i
+		}
+	}
+
+	for _, tc := range incoming {
+		if i, ok := index[tc.ID]; ok && tc.ID != "" {
+			merged[i] = tc
+			continue
+		}
+		if tc.ID != "" {
+			index[tc.ID] = len(merged)
+		}
+		merged = append(merged, tc)
+	}
+
+	return merged
+}
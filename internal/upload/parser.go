@@ -3,24 +3,28 @@ package upload
 import (
 	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
 )
 
+// filePathPattern matches .momorph/(testcases|specs)/(fileKey)/(frameId)-(frameName).csv
+// at the end of a slash-separated path. ".momorph" must be a whole path
+// segment, so whatever precedes it (a drive letter such as "C:", a UNC prefix
+// such as "//server/share" or "//?/C:") is ignored. The frame ID stops at the
+// first "-" and may itself contain colons (e.g. 9276:19907).
+var filePathPattern = regexp.MustCompile(`(?:^|/)\.momorph/(testcases|specs)/([^/]+)/([^/-]+)-([^/]+)\.csv$`)
+
 // ParseFilePath extracts metadata from file path
 // Expected format: .momorph/{testcases|specs}/{file_key}/{frame_id}-{frame_name}.csv
 // Example: .momorph/testcases/i09vM3jClQiu8cwXsMo6uy/9276:19907-TOP_Channel.csv
 func ParseFilePath(fullFilePath string) (*ParsedFilePath, error) {
-	// Normalize path separators
+	// Normalize path separators (Windows paths use backslashes)
 	normalizedPath := strings.ReplaceAll(fullFilePath, "\\", "/")
 
-	// Regex to match the expected pattern
-	// .momorph/(testcases|specs)/(fileKey)/(frameId)-(frameName).csv
-	regex := regexp.MustCompile(`\.momorph/(testcases|specs)/([^/]+)/([^-]+)-([^.]+)\.csv$`)
-
-	match := regex.FindStringSubmatch(normalizedPath)
+	match := filePathPattern.FindStringSubmatch(normalizedPath)
 	if match == nil {
 		return nil, fmt.Errorf("file path does not match expected pattern: .momorph/{testcases|specs}/{file_key}/{frame_id}-{frame_name}.csv")
 	}
@@ -51,7 +55,27 @@ func ParseTestcasesCSV(filePath string) (*TestCaseContent, error) {
 	}
 	defer file.Close()
 
-	reader := csv.NewReader(file)
+	// The screen name comes from the file path; CSV errors are reported first
+	parsed, pathErr := ParseFilePath(filePath)
+	screenName := ""
+	if pathErr == nil {
+		screenName = parsed.FrameName
+	}
+
+	content, err := ParseTestcasesReader(file, screenName)
+	if err != nil {
+		return nil, err
+	}
+	if pathErr != nil {
+		return nil, pathErr
+	}
+	return content, nil
+}
+
+// ParseTestcasesReader parses test cases CSV data read from r for the
+// screen (frame) named screenName
+func ParseTestcasesReader(r io.Reader, screenName string) (*TestCaseContent, error) {
+	reader := csv.NewReader(r)
 	reader.FieldsPerRecord = -1 // Allow variable number of fields
 
 	records, err := reader.ReadAll()
@@ -80,14 +104,8 @@ func ParseTestcasesCSV(filePath string) (*TestCaseContent, error) {
 		testCases = append(testCases, *tc)
 	}
 
-	// Extract screen name from file path
-	parsed, err := ParseFilePath(filePath)
-	if err != nil {
-		return nil, err
-	}
-
 	return &TestCaseContent{
-		ScreenName: parsed.FrameName,
+		ScreenName: screenName,
 		TestCases:  testCases,
 	}, nil
 }
@@ -95,7 +113,7 @@ func ParseTestcasesCSV(filePath string) (*TestCaseContent, error) {
 func parseTestcaseRow(row []string, colIndex map[string]int, lineNum int) (*TestCase, error) {
 	getValue := func(csvCol string) string {
 		if idx, ok := colIndex[csvCol]; ok && idx < len(row) {
-			return strings.TrimSpace(row[idx])
+			return normalizeCell(row[idx])
 		}
 		return ""
 	}
@@ -136,7 +154,12 @@ func ParseSpecsCSV(filePath string) ([]Spec, error) {
 	}
 	defer file.Close()
 
-	reader := csv.NewReader(file)
+	return ParseSpecsReader(file)
+}
+
+// ParseSpecsReader parses specs CSV data read from r, e.g. piped on stdin
+func ParseSpecsReader(r io.Reader) ([]Spec, error) {
+	reader := csv.NewReader(r)
 	reader.FieldsPerRecord = -1 // Allow variable number of fields
 
 	records, err := reader.ReadAll()
@@ -171,7 +194,7 @@ func ParseSpecsCSV(filePath string) ([]Spec, error) {
 func parseSpecRow(row []string, colIndex map[string]int, lineNum int) (*Spec, error) {
 	getValue := func(csvCol string) string {
 		if idx, ok := colIndex[csvCol]; ok && idx < len(row) {
-			return strings.TrimSpace(row[idx])
+			return normalizeCell(row[idx])
 		}
 		return ""
 	}
@@ -188,6 +211,9 @@ func parseSpecRow(row []string, colIndex map[string]int, lineNum int) (*Spec, er
 		return &num
 	}
 
+	// Unrecognized values are left unset and recorded as invalid, so the
+	// row fails validation instead of being uploaded as if the cell were blank
+	var invalid []string
 	getBool := func(csvCol string) *bool {
 		val := getValue(csvCol)
 		if val == "" {
@@ -202,11 +228,11 @@ func parseSpecRow(row []string, colIndex map[string]int, lineNum int) (*Spec, er
 			b := false
 			return &b
 		}
-		// Return nil for unrecognized values
+		invalid = append(invalid, fmt.Sprintf("%s must be true/false, yes/no or 1/0 (got %q)", csvCol, val))
 		return nil
 	}
 
-	return &Spec{
+	spec := &Spec{
 		No:             getValue("No"),
 		DesignItemName: getValue("itemName"),
 		Name:           getValue("nameJP"),
@@ -229,7 +255,23 @@ func parseSpecRow(row []string, colIndex map[string]int, lineNum int) (*Spec, er
 		ColumnName:     getValue("databaseColumn"),
 		DatabaseNote:   getValue("databaseNote"),
 		Description:    getValue("description"),
-	}, nil
+		IsReviewed:     getBool("reviewed"),
+		Status:         strings.ToLower(getValue("status")),
+		Row:            lineNum,
+	}
+	spec.InvalidValues = invalid
+	return spec, nil
+}
+
+// cellNewlineReplacer converts the carriage returns left in a cell to "\n"
+var cellNewlineReplacer = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+
+// normalizeCell trims surrounding whitespace and normalizes line endings in a
+// CSV cell. encoding/csv already turns "\r\n" inside quoted cells into "\n",
+// but keeps a lone "\r" (classic Mac line breaks, or the "\r\r\n" some Excel
+// exports write), which would otherwise reach the server as part of the text.
+func normalizeCell(value string) string {
+	return strings.TrimSpace(cellNewlineReplacer.Replace(value))
 }
 
 // TransformSpecToPayload transforms a Spec to SpecPayload for GraphQL mutation
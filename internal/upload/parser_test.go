@@ -0,0 +1,199 @@
+package upload
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFilePath(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		wantType  string
+		wantKey   string
+		wantFrame string
+		wantName  string
+		wantErr   bool
+	}{
+		{
+			name:      "relative",
+			path:      ".momorph/specs/abc123/9276:19907-TOP_Channel.csv",
+			wantType:  "specs",
+			wantKey:   "abc123",
+			wantFrame: "9276:19907",
+			wantName:  "TOP_Channel",
+		},
+		{
+			name:      "unix absolute",
+			path:      "/home/u/project/.momorph/testcases/abc123/1:2-Login.csv",
+			wantType:  "testcases",
+			wantKey:   "abc123",
+			wantFrame: "1:2",
+			wantName:  "Login",
+		},
+		{
+			name:      "windows absolute",
+			path:      `C:\Users\u\project\.momorph\specs\abc123\9276:19907-TOP_Channel.csv`,
+			wantType:  "specs",
+			wantKey:   "abc123",
+			wantFrame: "9276:19907",
+			wantName:  "TOP_Channel",
+		},
+		{
+			name:      "windows relative",
+			path:      `.momorph\testcases\abc123\1:2-Login-Page.csv`,
+			wantType:  "testcases",
+			wantKey:   "abc123",
+			wantFrame: "1:2",
+			wantName:  "Login-Page",
+		},
+		{
+			name:      "unc",
+			path:      `\\host\share\project\.momorph\specs\abc123\9276:19907-TOP_Channel.csv`,
+			wantType:  "specs",
+			wantKey:   "abc123",
+			wantFrame: "9276:19907",
+			wantName:  "TOP_Channel",
+		},
+		{
+			name:      "extended-length",
+			path:      `\\?\C:\project\.momorph\specs\abc123\I12:34;56:78-Item.csv`,
+			wantType:  "specs",
+			wantKey:   "abc123",
+			wantFrame: "I12:34;56:78",
+			wantName:  "Item",
+		},
+		{
+			name:    "momorph not a whole segment",
+			path:    `C:\project\x.momorph\specs\abc123\1:2-Login.csv`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown upload type",
+			path:    `C:\project\.momorph\other\abc123\1:2-Login.csv`,
+			wantErr: true,
+		},
+		{
+			name:    "missing frame name",
+			path:    `\\host\share\.momorph\specs\abc123\1:2.csv`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFilePath(tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseFilePath(%q) = %+v, want error", tt.path, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFilePath(%q): %v", tt.path, err)
+			}
+			if got.Type != tt.wantType || got.FileKey != tt.wantKey || got.FrameID != tt.wantFrame || got.FrameName != tt.wantName {
+				t.Errorf("ParseFilePath(%q) = %+v, want type=%s key=%s frame=%s name=%s",
+					tt.path, got, tt.wantType, tt.wantKey, tt.wantFrame, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestParseTestcasesReaderMultilineCells(t *testing.T) {
+	tests := []struct {
+		name         string
+		csv          string
+		wantSteps    string
+		wantExpected string
+	}{
+		{
+			name: "LF",
+			csv: "TC_ID,Steps,Expected_Result\n" +
+				"TC1,\"1. Open, then\n2. Click\",\"Shows A, B\nand C\"\n",
+			wantSteps:    "1. Open, then\n2. Click",
+			wantExpected: "Shows A, B\nand C",
+		},
+		{
+			name: "CRLF",
+			csv: "TC_ID,Steps,Expected_Result\r\n" +
+				"TC1,\"1. Open, then\r\n2. Click\",\"Shows A, B\r\nand C\"\r\n",
+			wantSteps:    "1. Open, then\n2. Click",
+			wantExpected: "Shows A, B\nand C",
+		},
+		{
+			name: "lone CR inside quoted cell",
+			csv: "TC_ID,Steps,Expected_Result\r\n" +
+				"TC1,\"1. Open\r2. Click\",\"Shows A\r\r\nand C\"\r\n",
+			wantSteps:    "1. Open\n2. Click",
+			wantExpected: "Shows A\nand C",
+		},
+		{
+			name: "surrounding whitespace trimmed",
+			csv: "TC_ID,Steps,Expected_Result\n" +
+				"TC1,\"  1. Open\n2. Click \n\",\" Done \"\n",
+			wantSteps:    "1. Open\n2. Click",
+			wantExpected: "Done",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := ParseTestcasesReader(strings.NewReader(tt.csv), "Screen")
+			if err != nil {
+				t.Fatalf("ParseTestcasesReader: %v", err)
+			}
+			if len(content.TestCases) != 1 {
+				t.Fatalf("got %d test cases, want 1", len(content.TestCases))
+			}
+			tc := content.TestCases[0]
+			if tc.ID != "TC1" {
+				t.Errorf("ID = %q, want TC1", tc.ID)
+			}
+			if tc.Step != tt.wantSteps {
+				t.Errorf("Step = %q, want %q", tc.Step, tt.wantSteps)
+			}
+			if tc.ExpectedResult != tt.wantExpected {
+				t.Errorf("ExpectedResult = %q, want %q", tc.ExpectedResult, tt.wantExpected)
+			}
+		})
+	}
+}
+
+func TestParseSpecsReaderInvalidBool(t *testing.T) {
+	csv := "No,itemId,nameJP,itemType,required,reviewed\n" +
+		"1,1:2,Email,text_form,yes,true\n" +
+		"2,1:3,Password,text_form,maybe,done\n"
+
+	specs, err := ParseSpecsReader(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("ParseSpecsReader() error = %v", err)
+	}
+	if len(specs) != 2 {
+		t.Fatalf("got %d specs, want 2", len(specs))
+	}
+
+	if specs[0].InvalidValues != nil {
+		t.Errorf("row 2 InvalidValues = %q, want none", specs[0].InvalidValues)
+	}
+
+	bad := specs[1]
+	if bad.Row != 3 {
+		t.Errorf("Row = %d, want 3", bad.Row)
+	}
+	if bad.Required != nil || bad.IsReviewed != nil {
+		t.Errorf("invalid cells parsed as Required=%v IsReviewed=%v, want unset", bad.Required, bad.IsReviewed)
+	}
+	want := []string{
+		`required must be true/false, yes/no or 1/0 (got "maybe")`,
+		`reviewed must be true/false, yes/no or 1/0 (got "done")`,
+	}
+	if strings.Join(bad.InvalidValues, "|") != strings.Join(want, "|") {
+		t.Errorf("InvalidValues = %q, want %q", bad.InvalidValues, want)
+	}
+
+	errs := ValidateSpecContent(&bad, DesignItemStatusDraft)
+	if len(errs) < 2 || errs[0] != want[0] || errs[1] != want[1] {
+		t.Errorf("ValidateSpecContent() = %q, want the invalid values reported", errs)
+	}
+}
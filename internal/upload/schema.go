@@ -0,0 +1,121 @@
+package upload
+
+import "strings"
+
+// ColumnSchema describes one CSV column accepted by the upload commands
+type ColumnSchema struct {
+	Column string `json:"column"` // CSV header name
+	Field  string `json:"field"`  // field the value is stored in
+	Type   string `json:"type"`   // string, integer or boolean
+	// Required is "completed" (required for completed specs) or ""
+	Required  string   `json:"required,omitempty"`
+	MaxLength int      `json:"max_length,omitempty"`
+	Values    []string `json:"values,omitempty"` // accepted values, if restricted
+	Note      string   `json:"note,omitempty"`
+}
+
+// booleanValues are the spellings parseSpecRow accepts for boolean columns
+var booleanValues = []string{"true", "false", "yes", "no", "1", "0"}
+
+// SpecColumns returns the columns of a specs CSV. Keep in sync with
+// parseSpecRow and ValidateSpecContent.
+func SpecColumns() []ColumnSchema {
+	return []ColumnSchema{
+		{Column: "No", Field: "no", Type: "string"},
+		{Column: "itemName", Field: "design_item_name", Type: "string"},
+		{Column: "nameJP", Field: "name", Type: "string", MaxLength: MaxNameLength},
+		{Column: "nameTrans", Field: "nameTrans", Type: "string", MaxLength: MaxNameTransLength},
+		{Column: "itemId", Field: "node_link_id", Type: "string", Note: "Figma node ID of the design item; must be unique within a file"},
+		{Column: "itemType", Field: "type", Type: "string", Required: "completed", Values: AcceptedOptionTypes},
+		{Column: "itemSubtype", Field: "otherType", Type: "string", MaxLength: MaxOtherTypeLength, Note: "used when itemType is others"},
+		{Column: "buttonType", Field: "buttonType", Type: "string", Values: AcceptedButtonTypes, Note: "used when itemType is button"},
+		{Column: "dataType", Field: "dataType", Type: "string", Values: AcceptedDataTypes, Note: "used when itemType is one of " + strings.Join(TypesRequiringDataType, ", ")},
+		{Column: "required", Field: "required", Type: "boolean", Values: booleanValues},
+		{Column: "format", Field: "format", Type: "string", MaxLength: MaxFormatLength},
+		{Column: "minLength", Field: "minLength", Type: "integer", Note: ">= 0 and at most maxLength (equal for a fixed length)"},
+		{Column: "maxLength", Field: "maxLength", Type: "integer", Note: ">= 0"},
+		{Column: "defaultValue", Field: "defaultValue", Type: "string", MaxLength: MaxDefaultValueLength},
+		{Column: "validationNote", Field: "validationNote", Type: "string", MaxLength: MaxValidationNoteLength},
+		{Column: "userAction", Field: "action", Type: "string", Values: AcceptedActionTypes},
+		{Column: "linkedFrameId", Field: "linkedFrameId", Type: "string", Note: "frame link id (Figma node id, e.g. 9276:19907) of a frame in the same file"},
+		{Column: "transitionNote", Field: "navigationNote", Type: "string", MaxLength: MaxNavigationNoteLength},
+		{Column: "databaseTable", Field: "tableName", Type: "string", MaxLength: MaxTableNameLength},
+		{Column: "databaseColumn", Field: "columnName", Type: "string", MaxLength: MaxColumnNameLength},
+		{Column: "databaseNote", Field: "databaseNote", Type: "string", MaxLength: MaxDatabaseNoteLength},
+		{Column: "description", Field: "description", Type: "string", MaxLength: MaxDescriptionLength},
+		{Column: "reviewed", Field: "is_reviewed", Type: "boolean", Values: booleanValues, Note: "blank keeps the server value"},
+		{Column: "status", Field: "status", Type: "string", Values: []string{DesignItemStatusNone, DesignItemStatusDraft, DesignItemStatusCompleted}, Note: "blank keeps the server value or infers it"},
+	}
+}
+
+// TestcaseColumns returns the columns of a test cases CSV. Keep in sync with
+// parseTestcaseRow.
+func TestcaseColumns() []ColumnSchema {
+	return []ColumnSchema{
+		{Column: "TC_ID", Field: "ID", Type: "string"},
+		{Column: "Steps", Field: "step", Type: "string"},
+		{Column: "Category", Field: "category", Type: "string"},
+		{Column: "Page_Name", Field: "page_name", Type: "string"},
+		{Column: "Section", Field: "test_area", Type: "string", Values: []string{"ACCESSING", "GUI", "FUNCTION"}, Note: "functional and function are sent as FUNCTION"},
+		{Column: "Test_Data", Field: "test_data", Type: "string"},
+		{Column: "Sub_Category", Field: "sub_category", Type: "string"},
+		{Column: "Sub_Sub_Category", Field: "sub_sub_category", Type: "string"},
+		{Column: "Precondition", Field: "pre_condition", Type: "string"},
+		{Column: "Expected_Result", Field: "expected_result", Type: "string"},
+		{Column: "Testcase_Type", Field: "tc_type", Type: "string"},
+		{Column: "Priority", Field: "priority", Type: "string"},
+		{Column: "Test_Results", Field: "test_results", Type: "string"},
+		{Column: "Executed_Date", Field: "executed_date", Type: "string"},
+		{Column: "Tester", Field: "tester", Type: "string"},
+		{Column: "Note", Field: "note", Type: "string"},
+	}
+}
+
+// SpecRuleset is the set of constraints ValidateSpecContent applies to a
+// spec, for tools that validate specs without running the CLI
+type SpecRuleset struct {
+	Statuses    []string       `json:"statuses"`
+	OptionTypes []string       `json:"option_types"`
+	DataTypes   []string       `json:"data_types"`
+	ButtonTypes []string       `json:"button_types"`
+	ActionTypes []string       `json:"action_types"`
+	MaxLengths  map[string]int `json:"max_lengths"` // keyed by field
+	// The rules below apply to specs with status completed; per item type
+	TypesRequiringDataType []string `json:"types_requiring_data_type"`
+	TypesRequiringLength   []string `json:"types_requiring_length"`
+	TypesWithoutValidation []string `json:"types_without_validation"`
+	// LinkedFrameIDPattern is the form a linkedFrameId must have
+	LinkedFrameIDPattern string         `json:"linked_frame_id_pattern"`
+	Columns              []ColumnSchema `json:"columns"`
+}
+
+// SpecRules returns the constraints used by ValidateSpecContent. Keep in
+// sync with it.
+func SpecRules() SpecRuleset {
+	return SpecRuleset{
+		Statuses:    []string{DesignItemStatusNone, DesignItemStatusDraft, DesignItemStatusCompleted},
+		OptionTypes: AcceptedOptionTypes,
+		DataTypes:   AcceptedDataTypes,
+		ButtonTypes: AcceptedButtonTypes,
+		ActionTypes: AcceptedActionTypes,
+		MaxLengths: map[string]int{
+			"name":           MaxNameLength,
+			"nameTrans":      MaxNameTransLength,
+			"buttonType":     MaxButtonTypeLength,
+			"otherType":      MaxOtherTypeLength,
+			"format":         MaxFormatLength,
+			"defaultValue":   MaxDefaultValueLength,
+			"tableName":      MaxTableNameLength,
+			"columnName":     MaxColumnNameLength,
+			"navigationNote": MaxNavigationNoteLength,
+			"validationNote": MaxValidationNoteLength,
+			"databaseNote":   MaxDatabaseNoteLength,
+			"description":    MaxDescriptionLength,
+		},
+		TypesRequiringDataType: TypesRequiringDataType,
+		TypesRequiringLength:   TypesRequiringLength,
+		TypesWithoutValidation: TypesWithoutValidation,
+		LinkedFrameIDPattern:   frameLinkIDPattern.String(),
+		Columns:                SpecColumns(),
+	}
+}
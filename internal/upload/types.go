@@ -1,5 +1,7 @@
 package upload
 
+import "encoding/json"
+
 // TestCase represents a single test case item
 type TestCase struct {
 	ID             string `json:"ID"`
@@ -34,6 +36,16 @@ const (
 	DesignItemStatusCompleted = "completed"
 )
 
+// FrameStatusDesign marks a frame that is still being designed; specs and
+// test cases cannot be uploaded to it yet
+const FrameStatusDesign = "design"
+
+// FrameAcceptsUploads reports whether specs and test cases can be uploaded
+// to a frame with the given status (matches the SDK's inDesignFrame check)
+func FrameAcceptsUploads(status string) bool {
+	return status != FrameStatusDesign
+}
+
 // Spec represents a single spec item from CSV
 type Spec struct {
 	No             string `json:"no"`
@@ -60,6 +72,10 @@ type Spec struct {
 	DatabaseNote   string `json:"databaseNote,omitempty"`
 	Description    string `json:"description,omitempty"`
 	IsReviewed     *bool  `json:"is_reviewed,omitempty"`
+	Status         string `json:"-"` // status requested in the CSV, empty to infer
+	Row            int    `json:"-"` // source CSV row (header is row 1), 0 if unknown
+	// InvalidValues lists the cells of the row whose value could not be parsed
+	InvalidValues []string `json:"-"`
 }
 
 // ValidatedSpec represents a spec with validation results
@@ -73,6 +89,14 @@ type ValidatedSpec struct {
 	Existing *Spec    // reference to existing spec if any
 }
 
+// SpecFieldDiff describes one field that differs between a CSV spec and
+// the spec currently stored on the server
+type SpecFieldDiff struct {
+	Field    string      // comparison key from MapSpecForComparison
+	Previous interface{} // server value, nil if unset
+	Current  interface{} // CSV value, nil if unset
+}
+
 // SpecPayload represents the transformed payload for GraphQL mutation
 type SpecPayload struct {
 	Type          string       `json:"type,omitempty"`
@@ -157,15 +181,53 @@ type UploadResult struct {
 	Status   UploadStatus
 	Error    error
 	Message  string
+	Details  []string // per-row problems worth showing to the user
+	// Unchanged counts rows that matched the server and were not sent
+	Unchanged int
+}
+
+// MarshalJSON encodes the result with the file key and frame ID from its
+// path, and Error as its message string
+func (r UploadResult) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Type      string       `json:"type,omitempty"`
+		FilePath  string       `json:"file_path"`
+		FileName  string       `json:"file_name"`
+		FileKey   string       `json:"file_key,omitempty"`
+		FrameID   string       `json:"frame_id,omitempty"`
+		Status    UploadStatus `json:"status"`
+		Message   string       `json:"message,omitempty"`
+		Error     string       `json:"error,omitempty"`
+		Details   []string     `json:"details,omitempty"`
+		Unchanged int          `json:"unchanged,omitempty"`
+	}{
+		FilePath:  r.FilePath,
+		FileName:  r.FileName,
+		Status:    r.Status,
+		Message:   r.Message,
+		Details:   r.Details,
+		Unchanged: r.Unchanged,
+	}
+	if parsed, err := ParseFilePath(r.FilePath); err == nil {
+		out.Type = parsed.Type
+		out.FileKey = parsed.FileKey
+		out.FrameID = parsed.FrameID
+	}
+	if r.Error != nil {
+		out.Error = r.Error.Error()
+	}
+	return json.Marshal(out)
 }
 
 // UploadSummary contains aggregated upload results
 type UploadSummary struct {
-	Total     int
-	Success   int
-	Failed    int
-	Skipped   int
-	Results   []UploadResult
+	Total   int `json:"total"`
+	Success int `json:"success"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+	// Unchanged is the total of rows skipped because they matched the server
+	Unchanged int            `json:"unchanged"`
+	Results   []UploadResult `json:"results"`
 }
 
 // NewUploadSummary creates a new UploadSummary from results
@@ -175,6 +237,7 @@ func NewUploadSummary(results []UploadResult) *UploadSummary {
 		Results: results,
 	}
 	for _, r := range results {
+		summary.Unchanged += r.Unchanged
 		switch r.Status {
 		case StatusSuccess:
 			summary.Success++
@@ -3,9 +3,14 @@ package upload
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
 )
 
-// Length constraints matching SDK's UpdateSpecDto
+// Length constraints matching SDK's UpdateSpecDto, in characters rather than
+// bytes, so Japanese or Vietnamese text gets the same limit as ASCII
 const (
 	MaxNameLength           = 255
 	MaxNameTransLength      = 255
@@ -41,6 +46,31 @@ var TypesRequiringDataType = []string{"textarea", "text_form", "others"}
 var TypesRequiringLength = []string{"textarea", "text_form", "file_or_image", "video", "others"}
 var TypesWithoutValidation = []string{"button", "label"}
 
+var (
+	// frameLinkIDPattern matches a Figma node id as stored in frame_link_id,
+	// e.g. 9276:19907 (instance ids chain segments with ';')
+	frameLinkIDPattern = regexp.MustCompile(`^I?\d+:\d+(;\d+:\d+)*$`)
+	// frameURLIDPattern matches the node-id form used in Figma URLs, 9276-19907
+	frameURLIDPattern = regexp.MustCompile(`^\d+-\d+$`)
+	// internalIDPattern matches a bare database id
+	internalIDPattern = regexp.MustCompile(`^\d+$`)
+)
+
+// ValidateLinkedFrameID checks that id has the form of a frame link id and
+// returns an error message naming the likely mistake, or "" if it is valid
+func ValidateLinkedFrameID(id string) string {
+	switch {
+	case frameLinkIDPattern.MatchString(id):
+		return ""
+	case internalIDPattern.MatchString(id):
+		return fmt.Sprintf("linkedFrameId %q looks like an internal frame id; expected the frame's link id (Figma node id, e.g. 9276:19907)", id)
+	case frameURLIDPattern.MatchString(id):
+		return fmt.Sprintf("linkedFrameId %q is in Figma URL form; write it with a colon, e.g. %s", id, strings.Replace(id, "-", ":", 1))
+	default:
+		return fmt.Sprintf("linkedFrameId %q is not a frame link id (expected a Figma node id, e.g. 9276:19907)", id)
+	}
+}
+
 // contains checks if a slice contains a string
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -53,7 +83,8 @@ func contains(slice []string, item string) bool {
 
 // ValidateSpecContent validates a spec content using the same validation logic as SDK's UpdateSpecDto
 func ValidateSpecContent(spec *Spec, status string) []string {
-	var errors []string
+	// Cells the parser could not read
+	errors := append([]string(nil), spec.InvalidValues...)
 	isCompleted := status == DesignItemStatusCompleted
 	itemType := spec.Type
 
@@ -68,13 +99,13 @@ func ValidateSpecContent(spec *Spec, status string) []string {
 
 	// ==================== ITEM SPECS VALIDATION ====================
 	// name validation
-	if (isCompleted || spec.Name != "") && len(spec.Name) > MaxNameLength {
-		errors = append(errors, fmt.Sprintf("name must not exceed %d characters", MaxNameLength))
+	if (isCompleted || spec.Name != "") && utf8.RuneCountInString(spec.Name) > MaxNameLength {
+		errors = append(errors, tooLongError("name", spec.Name, MaxNameLength))
 	}
 
 	// nameTrans validation
-	if (isCompleted || spec.NameTrans != "") && len(spec.NameTrans) > MaxNameTransLength {
-		errors = append(errors, fmt.Sprintf("nameTrans must not exceed %d characters", MaxNameTransLength))
+	if (isCompleted || spec.NameTrans != "") && utf8.RuneCountInString(spec.NameTrans) > MaxNameTransLength {
+		errors = append(errors, tooLongError("nameTrans", spec.NameTrans, MaxNameTransLength))
 	}
 
 	// buttonType validation - required when type is BUTTON and status is COMPLETED
@@ -86,8 +117,8 @@ func ValidateSpecContent(spec *Spec, status string) []string {
 
 	// otherType validation - required when type is OTHERS and status is COMPLETED
 	if (itemType == "others" && isCompleted) || spec.OtherType != "" {
-		if len(spec.OtherType) > MaxOtherTypeLength {
-			errors = append(errors, fmt.Sprintf("otherType must not exceed %d characters", MaxOtherTypeLength))
+		if utf8.RuneCountInString(spec.OtherType) > MaxOtherTypeLength {
+			errors = append(errors, tooLongError("otherType", spec.OtherType, MaxOtherTypeLength))
 		}
 	}
 
@@ -99,10 +130,17 @@ func ValidateSpecContent(spec *Spec, status string) []string {
 		}
 	}
 
+	// linkedFrameId format; existence is checked against the server on upload
+	if spec.LinkedFrameID != "" {
+		if msg := ValidateLinkedFrameID(spec.LinkedFrameID); msg != "" {
+			errors = append(errors, msg)
+		}
+	}
+
 	// navigationNote validation
 	if (spec.Action != "" && isCompleted) || spec.NavigationNote != "" {
-		if len(spec.NavigationNote) > MaxNavigationNoteLength {
-			errors = append(errors, fmt.Sprintf("navigationNote must not exceed %d characters", MaxNavigationNoteLength))
+		if utf8.RuneCountInString(spec.NavigationNote) > MaxNavigationNoteLength {
+			errors = append(errors, tooLongError("navigationNote", spec.NavigationNote, MaxNavigationNoteLength))
 		}
 	}
 
@@ -116,8 +154,8 @@ func ValidateSpecContent(spec *Spec, status string) []string {
 
 	// format validation
 	if (!contains(TypesWithoutValidation, itemType) && isCompleted) || spec.Format != "" {
-		if len(spec.Format) > MaxFormatLength {
-			errors = append(errors, fmt.Sprintf("format must not exceed %d characters", MaxFormatLength))
+		if utf8.RuneCountInString(spec.Format) > MaxFormatLength {
+			errors = append(errors, tooLongError("format", spec.Format, MaxFormatLength))
 		}
 	}
 
@@ -136,49 +174,105 @@ func ValidateSpecContent(spec *Spec, status string) []string {
 		}
 	}
 
-	// Cross-field validation: minLength < maxLength
+	// Cross-field validation: minLength <= maxLength; equal values describe
+	// a fixed-length field
 	if spec.MinLength != nil && spec.MaxLength != nil {
-		if *spec.MinLength >= *spec.MaxLength {
-			errors = append(errors, "minLength must be less than maxLength")
+		if *spec.MinLength > *spec.MaxLength {
+			errors = append(errors, fmt.Sprintf("minLength (%d) must be ≤ maxLength (%d)", *spec.MinLength, *spec.MaxLength))
 		}
 	}
 
 	// defaultValue validation
-	if (isCompleted || spec.DefaultValue != "") && len(spec.DefaultValue) > MaxDefaultValueLength {
-		errors = append(errors, fmt.Sprintf("defaultValue must not exceed %d characters", MaxDefaultValueLength))
+	if (isCompleted || spec.DefaultValue != "") && utf8.RuneCountInString(spec.DefaultValue) > MaxDefaultValueLength {
+		errors = append(errors, tooLongError("defaultValue", spec.DefaultValue, MaxDefaultValueLength))
 	}
 
 	// validationNote validation
-	if (isCompleted || spec.ValidationNote != "") && len(spec.ValidationNote) > MaxValidationNoteLength {
-		errors = append(errors, fmt.Sprintf("validationNote must not exceed %d characters", MaxValidationNoteLength))
+	if (isCompleted || spec.ValidationNote != "") && utf8.RuneCountInString(spec.ValidationNote) > MaxValidationNoteLength {
+		errors = append(errors, tooLongError("validationNote", spec.ValidationNote, MaxValidationNoteLength))
 	}
 
 	// ==================== DATABASE SPECS VALIDATION ====================
 	requiresDatabase := isCompleted && itemType != "button"
 
 	// tableName validation
-	if (requiresDatabase || spec.TableName != "") && len(spec.TableName) > MaxTableNameLength {
-		errors = append(errors, fmt.Sprintf("tableName must not exceed %d characters", MaxTableNameLength))
+	if (requiresDatabase || spec.TableName != "") && utf8.RuneCountInString(spec.TableName) > MaxTableNameLength {
+		errors = append(errors, tooLongError("tableName", spec.TableName, MaxTableNameLength))
 	}
 
 	// columnName validation
-	if (requiresDatabase || spec.ColumnName != "") && len(spec.ColumnName) > MaxColumnNameLength {
-		errors = append(errors, fmt.Sprintf("columnName must not exceed %d characters", MaxColumnNameLength))
+	if (requiresDatabase || spec.ColumnName != "") && utf8.RuneCountInString(spec.ColumnName) > MaxColumnNameLength {
+		errors = append(errors, tooLongError("columnName", spec.ColumnName, MaxColumnNameLength))
 	}
 
 	// databaseNote validation
-	if (requiresDatabase || spec.DatabaseNote != "") && len(spec.DatabaseNote) > MaxDatabaseNoteLength {
-		errors = append(errors, fmt.Sprintf("databaseNote must not exceed %d characters", MaxDatabaseNoteLength))
+	if (requiresDatabase || spec.DatabaseNote != "") && utf8.RuneCountInString(spec.DatabaseNote) > MaxDatabaseNoteLength {
+		errors = append(errors, tooLongError("databaseNote", spec.DatabaseNote, MaxDatabaseNoteLength))
 	}
 
 	// ==================== DESCRIPTION VALIDATION ====================
-	if (isCompleted || spec.Description != "") && len(spec.Description) > MaxDescriptionLength {
-		errors = append(errors, fmt.Sprintf("description must not exceed %d characters", MaxDescriptionLength))
+	if (isCompleted || spec.Description != "") && utf8.RuneCountInString(spec.Description) > MaxDescriptionLength {
+		errors = append(errors, tooLongError("description", spec.Description, MaxDescriptionLength))
 	}
 
 	return errors
 }
 
+// FindDuplicateSpecs splits specs into those with a unique node_link_id and
+// later rows that repeat an itemId already seen in the same file.
+// Duplicates are returned as invalid specs so they are never upserted.
+func FindDuplicateSpecs(specs []Spec) ([]Spec, []ValidatedSpec) {
+	firstRow := make(map[string]int)
+	var unique []Spec
+	var duplicates []ValidatedSpec
+
+	for _, spec := range specs {
+		if spec.NodeLinkID == "" {
+			unique = append(unique, spec)
+			continue
+		}
+
+		if first, seen := firstRow[spec.NodeLinkID]; seen {
+			duplicates = append(duplicates, ValidatedSpec{
+				Spec:    spec,
+				IsValid: false,
+				Errors:  []string{fmt.Sprintf("duplicate itemId (first defined at row %d)", first)},
+			})
+			continue
+		}
+
+		firstRow[spec.NodeLinkID] = spec.Row
+		unique = append(unique, spec)
+	}
+
+	return unique, duplicates
+}
+
+// ValidateSpecs runs duplicate detection, status determination and content
+// validation over parsed specs. It never consults the server, so checks that
+// need existing items (deleted items, linked frames) are not covered.
+func ValidateSpecs(specs []Spec) ([]ValidatedSpec, []ValidatedSpec) {
+	unique, invalid := FindDuplicateSpecs(specs)
+	var valid []ValidatedSpec
+
+	for _, spec := range unique {
+		status, validationErrors := DetermineSpecStatus(&spec, "")
+		validated := ValidatedSpec{
+			Spec:    spec,
+			Status:  status,
+			IsValid: len(validationErrors) == 0,
+			Errors:  validationErrors,
+		}
+		if validated.IsValid {
+			valid = append(valid, validated)
+		} else {
+			invalid = append(invalid, validated)
+		}
+	}
+
+	return valid, invalid
+}
+
 // IsSpecContentEmpty checks if spec content is empty (only contains structural/metadata fields)
 func IsSpecContentEmpty(spec *Spec) bool {
 	if spec == nil {
@@ -266,12 +360,71 @@ func CompareSpecs(current, previous map[string]interface{}) bool {
 	return reflect.DeepEqual(current, previous)
 }
 
+// DiffSpecs returns the fields that differ between two maps produced by
+// MapSpecForComparison, sorted by field name. A nil previous map (new item)
+// reports every field that has a value in current.
+func DiffSpecs(current, previous map[string]interface{}) []SpecFieldDiff {
+	var diffs []SpecFieldDiff
+	for field, value := range current {
+		prev := previous[field]
+		if isEmptyComparisonValue(value) && isEmptyComparisonValue(prev) {
+			continue
+		}
+		if !reflect.DeepEqual(value, prev) {
+			diffs = append(diffs, SpecFieldDiff{Field: field, Previous: prev, Current: value})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+	return diffs
+}
+
+// isEmptyComparisonValue reports whether a comparison value is unset
+func isEmptyComparisonValue(value interface{}) bool {
+	return value == nil || value == ""
+}
+
 // DetermineSpecStatus determines the appropriate status for a spec
 // Returns (status, validationErrors)
+//
+// Precedence, highest first:
+//  1. The CSV status column (spec.Status): none, draft or completed. Draft
+//     and completed rows must pass validation for that status.
+//  2. existingStatus, the status currently stored on the server ("" for new
+//     items). A draft item stays draft and a completed item stays completed,
+//     so a re-upload never silently promotes or demotes it. An edit that
+//     breaks the completed rules is rejected with a hint to demote the item
+//     explicitly.
+//  3. Inference: "none" if the content is empty, completed if it passes
+//     completed validation, otherwise draft.
 func DetermineSpecStatus(spec *Spec, existingStatus string) (string, []string) {
+	switch spec.Status {
+	case "":
+		// No explicit status, fall through to existing status and inference
+	case DesignItemStatusNone:
+		return DesignItemStatusNone, spec.InvalidValues
+	case DesignItemStatusDraft, DesignItemStatusCompleted:
+		return spec.Status, ValidateSpecContent(spec, spec.Status)
+	default:
+		return DesignItemStatusDraft, []string{
+			fmt.Sprintf("status %q is not valid (must be none, draft or completed)", spec.Status),
+		}
+	}
+
 	// If spec content is empty, status is "none"
 	if IsSpecContentEmpty(spec) {
-		return DesignItemStatusNone, nil
+		return DesignItemStatusNone, spec.InvalidValues
+	}
+
+	switch existingStatus {
+	case DesignItemStatusDraft:
+		return existingStatus, ValidateSpecContent(spec, existingStatus)
+	case DesignItemStatusCompleted:
+		errs := ValidateSpecContent(spec, existingStatus)
+		if len(errs) > 0 {
+			errs = append([]string{"existing status is completed; set status=draft to demote"}, errs...)
+		}
+		return existingStatus, errs
 	}
 
 	// Try COMPLETED status first
@@ -289,3 +442,18 @@ func DetermineSpecStatus(spec *Spec, existingStatus string) (string, []string) {
 	// Return draft errors if both fail (draft is more lenient)
 	return DesignItemStatusDraft, draftErrors
 }
+
+// previewLength is how many characters of an over-long value are quoted in
+// its validation error
+const previewLength = 30
+
+// tooLongError describes a value over its length limit, with its actual
+// length and the start of the value so the cell is easy to find, e.g.
+// name is 312 characters (max 255): "Lorem ipsum dolor sit amet, co..."
+func tooLongError(field, value string, limit int) string {
+	preview := value
+	if runes := []rune(value); len(runes) > previewLength {
+		preview = string(runes[:previewLength]) + "..."
+	}
+	return fmt.Sprintf("%s is %d characters (max %d): %q", field, utf8.RuneCountInString(value), limit, preview)
+}
@@ -0,0 +1,264 @@
+package upload
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindDuplicateSpecs(t *testing.T) {
+	tests := []struct {
+		name           string
+		csv            string
+		wantUniqueRows []int
+		wantDupRows    []int
+		wantDupErrors  []string
+	}{
+		{
+			name: "no duplicates",
+			csv: "No,itemId,nameJP\n" +
+				"1,1:1,A\n" +
+				"2,1:2,B\n",
+			wantUniqueRows: []int{2, 3},
+		},
+		{
+			name: "duplicated itemId",
+			csv: "No,itemId,nameJP\n" +
+				"1,1:1,A\n" +
+				"2,1:2,B\n" +
+				"3,1:1,C\n",
+			wantUniqueRows: []int{2, 3},
+			wantDupRows:    []int{4},
+			wantDupErrors:  []string{"duplicate itemId (first defined at row 2)"},
+		},
+		{
+			name: "itemId repeated twice",
+			csv: "No,itemId,nameJP\n" +
+				"1,1:2,A\n" +
+				"2,1:2,B\n" +
+				"3,1:2,C\n",
+			wantUniqueRows: []int{2},
+			wantDupRows:    []int{3, 4},
+			wantDupErrors: []string{
+				"duplicate itemId (first defined at row 2)",
+				"duplicate itemId (first defined at row 2)",
+			},
+		},
+		{
+			name: "rows without itemId are not duplicates",
+			csv: "No,itemId,nameJP\n" +
+				"1,,A\n" +
+				"2,,B\n",
+			wantUniqueRows: []int{2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specs, err := ParseSpecsReader(strings.NewReader(tt.csv))
+			if err != nil {
+				t.Fatalf("ParseSpecsReader: %v", err)
+			}
+
+			unique, duplicates := FindDuplicateSpecs(specs)
+
+			var uniqueRows []int
+			for _, spec := range unique {
+				uniqueRows = append(uniqueRows, spec.Row)
+			}
+			if !equalInts(uniqueRows, tt.wantUniqueRows) {
+				t.Errorf("unique rows = %v, want %v", uniqueRows, tt.wantUniqueRows)
+			}
+
+			var dupRows []int
+			var dupErrors []string
+			for _, dup := range duplicates {
+				if dup.IsValid {
+					t.Errorf("duplicate row %d is marked valid", dup.Spec.Row)
+				}
+				dupRows = append(dupRows, dup.Spec.Row)
+				dupErrors = append(dupErrors, dup.Errors...)
+			}
+			if !equalInts(dupRows, tt.wantDupRows) {
+				t.Errorf("duplicate rows = %v, want %v", dupRows, tt.wantDupRows)
+			}
+			if strings.Join(dupErrors, "|") != strings.Join(tt.wantDupErrors, "|") {
+				t.Errorf("duplicate errors = %q, want %q", dupErrors, tt.wantDupErrors)
+			}
+		})
+	}
+}
+
+func TestValidateSpecsSkipsDuplicates(t *testing.T) {
+	csv := "No,itemId,nameJP,status\n" +
+		"1,1:1,A,draft\n" +
+		"2,1:1,B,draft\n"
+	specs, err := ParseSpecsReader(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("ParseSpecsReader: %v", err)
+	}
+
+	valid, invalid := ValidateSpecs(specs)
+	if len(valid) != 1 || valid[0].Spec.Name != "A" {
+		t.Fatalf("valid = %+v, want only the first row", valid)
+	}
+	if len(invalid) != 1 || invalid[0].Spec.Row != 3 {
+		t.Fatalf("invalid = %+v, want row 3", invalid)
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDetermineSpecStatus(t *testing.T) {
+	// completable passes the completed rules; draftOnly lacks a type, so it
+	// only passes the draft rules
+	completable := Spec{Name: "Title", Type: "label"}
+	draftOnly := Spec{Name: "Title"}
+
+	tests := []struct {
+		name      string
+		spec      Spec
+		csvStatus string
+		existing  string
+		want      string
+		wantErr   string // substring of the first error, "" for none
+	}{
+		// Blank CSV status: keep the server status, infer for new items
+		{"blank/new/completable", completable, "", "", DesignItemStatusCompleted, ""},
+		{"blank/new/draftOnly", draftOnly, "", "", DesignItemStatusDraft, ""},
+		{"blank/draft/completable", completable, "", DesignItemStatusDraft, DesignItemStatusDraft, ""},
+		{"blank/draft/draftOnly", draftOnly, "", DesignItemStatusDraft, DesignItemStatusDraft, ""},
+		{"blank/completed/completable", completable, "", DesignItemStatusCompleted, DesignItemStatusCompleted, ""},
+		{"blank/completed/draftOnly", draftOnly, "", DesignItemStatusCompleted, DesignItemStatusCompleted, "existing status is completed; set status=draft to demote"},
+		{"blank/new/empty", Spec{}, "", "", DesignItemStatusNone, ""},
+		{"blank/completed/empty", Spec{}, "", DesignItemStatusCompleted, DesignItemStatusNone, ""},
+
+		// none always wins
+		{"none/new", completable, "none", "", DesignItemStatusNone, ""},
+		{"none/draft", completable, "none", DesignItemStatusDraft, DesignItemStatusNone, ""},
+		{"none/completed", completable, "none", DesignItemStatusCompleted, DesignItemStatusNone, ""},
+
+		// draft is honored whatever the server has, including a demotion
+		{"draft/new", completable, "draft", "", DesignItemStatusDraft, ""},
+		{"draft/draft", draftOnly, "draft", DesignItemStatusDraft, DesignItemStatusDraft, ""},
+		{"draft/completed", draftOnly, "draft", DesignItemStatusCompleted, DesignItemStatusDraft, ""},
+
+		// completed is honored but must pass the completed rules
+		{"completed/new/completable", completable, "completed", "", DesignItemStatusCompleted, ""},
+		{"completed/draft/completable", completable, "completed", DesignItemStatusDraft, DesignItemStatusCompleted, ""},
+		{"completed/completed/completable", completable, "completed", DesignItemStatusCompleted, DesignItemStatusCompleted, ""},
+		{"completed/new/draftOnly", draftOnly, "completed", "", DesignItemStatusCompleted, "type is required"},
+		{"completed/draft/draftOnly", draftOnly, "completed", DesignItemStatusDraft, DesignItemStatusCompleted, "type is required"},
+		{"completed/completed/draftOnly", draftOnly, "completed", DesignItemStatusCompleted, DesignItemStatusCompleted, "type is required"},
+
+		{"invalid status", completable, "done", "", DesignItemStatusDraft, `status "done" is not valid`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := tt.spec
+			spec.Status = tt.csvStatus
+
+			got, errs := DetermineSpecStatus(&spec, tt.existing)
+			if got != tt.want {
+				t.Errorf("status = %q, want %q", got, tt.want)
+			}
+			if tt.wantErr == "" {
+				if len(errs) > 0 {
+					t.Errorf("unexpected errors: %q", errs)
+				}
+				return
+			}
+			if len(errs) == 0 || !strings.Contains(errs[0], tt.wantErr) {
+				t.Errorf("errors = %q, want first containing %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSpecContentLengthRange(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name    string
+		min     *int
+		max     *int
+		wantErr string // "" for no length error
+	}{
+		{"equal (fixed length)", intPtr(10), intPtr(10), ""},
+		{"min below max", intPtr(1), intPtr(10), ""},
+		{"only min", intPtr(5), nil, ""},
+		{"only max", nil, intPtr(5), ""},
+		{"zero range", intPtr(0), intPtr(0), ""},
+		{"min above max", intPtr(10), intPtr(5), "minLength (10) must be ≤ maxLength (5)"},
+		{"negative min", intPtr(-1), intPtr(5), "minLength must be greater than or equal to 0"},
+		{"negative max", nil, intPtr(-1), "maxLength must be greater than or equal to 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := Spec{Name: "Field", Type: "text_form", DataType: "string", MinLength: tt.min, MaxLength: tt.max}
+			errs := ValidateSpecContent(&spec, DesignItemStatusDraft)
+
+			var lengthErrs []string
+			for _, e := range errs {
+				if strings.Contains(e, "Length") {
+					lengthErrs = append(lengthErrs, e)
+				}
+			}
+			if tt.wantErr == "" {
+				if len(lengthErrs) > 0 {
+					t.Errorf("unexpected errors: %q", lengthErrs)
+				}
+				return
+			}
+			if len(lengthErrs) != 1 || lengthErrs[0] != tt.wantErr {
+				t.Errorf("errors = %q, want [%q]", lengthErrs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSpecContentCountsCharacters(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string // prefix of the name error, "" for none
+	}{
+		{"ascii at limit", strings.Repeat("a", MaxNameLength), ""},
+		{"japanese at limit", strings.Repeat("名", MaxNameLength), ""},
+		{"vietnamese at limit", strings.Repeat("ệ", MaxNameLength), ""},
+		{"ascii over limit", strings.Repeat("a", MaxNameLength+1), "name is 256 characters (max 255)"},
+		{"japanese over limit", strings.Repeat("名", MaxNameLength+1), "name is 256 characters (max 255)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := Spec{Name: tt.value}
+			var nameErrs []string
+			for _, e := range ValidateSpecContent(&spec, DesignItemStatusDraft) {
+				if strings.HasPrefix(e, "name ") {
+					nameErrs = append(nameErrs, e)
+				}
+			}
+			if tt.wantErr == "" {
+				if len(nameErrs) > 0 {
+					t.Errorf("unexpected errors: %q", nameErrs)
+				}
+				return
+			}
+			if len(nameErrs) != 1 || !strings.HasPrefix(nameErrs[0], tt.wantErr) {
+				t.Errorf("errors = %q, want one starting with %q", nameErrs, tt.wantErr)
+			}
+		})
+	}
+}
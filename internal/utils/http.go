@@ -3,15 +3,21 @@ package utils
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"math/rand"
 	"net"
 	"net/http"
-	"net/http/httputil"
+	"net/url"
+	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/momorph/cli/internal/i18n"
 	"github.com/momorph/cli/internal/logger"
 	"github.com/momorph/cli/internal/version"
 )
@@ -25,36 +31,108 @@ type HTTPClientConfig struct {
 	ConnectTimeout time.Duration
 }
 
+// defaultHTTPConfig is the process-wide configuration used by NewHTTPClient.
+// Commands may override it at startup via SetDefaultHTTPConfig.
+var defaultHTTPConfig = HTTPClientConfig{
+	Timeout:        30 * time.Second,
+	MaxRetries:     3,
+	RetryBaseDelay: 1 * time.Second,
+	Debug:          false,
+	ConnectTimeout: 10 * time.Second,
+}
+
 // DefaultHTTPConfig returns the default HTTP client configuration
 func DefaultHTTPConfig() HTTPClientConfig {
-	return HTTPClientConfig{
-		Timeout:        30 * time.Second,
-		MaxRetries:     3,
-		RetryBaseDelay: 1 * time.Second,
-		Debug:          false,
-		ConnectTimeout: 10 * time.Second,
-	}
+	return defaultHTTPConfig
 }
 
-// NewHTTPClient creates a new HTTP client with standard configuration
+// SetDefaultHTTPConfig replaces the default HTTP client configuration.
+// It should be called once at startup, before any client is created.
+func SetDefaultHTTPConfig(cfg HTTPClientConfig) {
+	defaultHTTPConfig = cfg
+}
+
+// NewHTTPClient creates a new HTTP client with standard configuration.
+// Every client in the CLI should come from here (or NewHTTPClientWithConfig)
+// so they share pooled connections, User-Agent, request IDs and debug logging.
 func NewHTTPClient() *http.Client {
 	return NewHTTPClientWithConfig(DefaultHTTPConfig())
 }
 
-// NewHTTPClientWithConfig creates a new HTTP client with custom configuration
-func NewHTTPClientWithConfig(cfg HTTPClientConfig) *http.Client {
+// NewHTTPClientWithTimeout creates a standard HTTP client with a different
+// overall timeout, e.g. for large downloads
+func NewHTTPClientWithTimeout(timeout time.Duration) *http.Client {
+	cfg := DefaultHTTPConfig()
+	cfg.Timeout = timeout
+	return NewHTTPClientWithConfig(cfg)
+}
+
+var (
+	sharedTransportOnce  sync.Once
+	sharedTransport      *http.Transport
+	sharedConnectTimeout time.Duration
+)
+
+// baseTransport returns the transport shared by all clients, so connections
+// to the same host are reused across clients. A config with a different
+// connect timeout gets its own transport.
+func baseTransport(connectTimeout time.Duration) *http.Transport {
+	sharedTransportOnce.Do(func() {
+		sharedConnectTimeout = DefaultHTTPConfig().ConnectTimeout
+		sharedTransport = newTransport(sharedConnectTimeout)
+	})
+	if connectTimeout == sharedConnectTimeout {
+		return sharedTransport
+	}
+	return newTransport(connectTimeout)
+}
+
+// insecureSkipVerifyEnv disables TLS certificate verification when set to a
+// true value. It exists only to reach staging servers with self-signed
+// certificates; it is deliberately an environment variable rather than a
+// flag or config setting, so it cannot be persisted or used casually.
+const insecureSkipVerifyEnv = "MOMORPH_INSECURE_SKIP_TLS_VERIFY"
+
+var insecureWarningOnce sync.Once
+
+// insecureSkipVerify reports whether TLS verification is disabled, printing
+// a warning to stderr the first time it is
+func insecureSkipVerify() bool {
+	switch strings.ToLower(os.Getenv(insecureSkipVerifyEnv)) {
+	case "1", "true", "yes":
+	default:
+		return false
+	}
+	insecureWarningOnce.Do(func() {
+		fmt.Fprintf(os.Stderr, "⚠ WARNING: %s is set. TLS certificates are NOT verified and connections can be intercepted. Use this only for staging servers.\n", insecureSkipVerifyEnv)
+	})
+	return true
+}
+
+// newTransport creates the underlying transport. Proxies are taken from the
+// HTTPS_PROXY/HTTP_PROXY/NO_PROXY environment variables.
+func newTransport(connectTimeout time.Duration) *http.Transport {
 	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
-			Timeout:   cfg.ConnectTimeout,
+			Timeout:   connectTimeout,
 			KeepAlive: 30 * time.Second,
 		}).DialContext,
 		MaxIdleConns:          100,
 		IdleConnTimeout:       90 * time.Second,
 		TLSHandshakeTimeout:   10 * time.Second,
 		ExpectContinueTimeout: 1 * time.Second,
-		// Force HTTPS only by not allowing proxy environment variables for plain HTTP
-		ForceAttemptHTTP2: true,
+		ForceAttemptHTTP2:     true,
+	}
+	if insecureSkipVerify() {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 	}
+	return transport
+}
+
+// NewHTTPClientWithConfig creates a new HTTP client with custom configuration
+func NewHTTPClientWithConfig(cfg HTTPClientConfig) *http.Client {
+	transport := baseTransport(cfg.ConnectTimeout)
 
 	return &http.Client{
 		Timeout: cfg.Timeout,
@@ -76,6 +154,10 @@ func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, er
 	requestID := generateRequestID()
 	req.Header.Set("User-Agent", "MoMorph-CLI/"+version.Version)
 	req.Header.Set("X-Request-ID", requestID)
+	// Let the server localize its error messages like the CLI's own output
+	if req.Header.Get("Accept-Language") == "" {
+		req.Header.Set("Accept-Language", i18n.Language())
+	}
 
 	start := time.Now()
 
@@ -99,13 +181,75 @@ func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, er
 		logger.Debug("HTTP %s %s → ERROR: %v (%v)", req.Method, sanitizeURL(req.URL.String()), err, duration)
 	}
 
+	notifyRequestObserver(req, resp, err, duration)
+
 	return resp, err
 }
 
+// RequestTiming describes a completed HTTP round trip
+type RequestTiming struct {
+	Method string
+	// Endpoint is the label set with WithRequestLabel, or the sanitized URL
+	// without its query string
+	Endpoint string
+	// Status is the response status code, or 0 if the request failed
+	Status   int
+	Duration time.Duration
+	Err      error
+}
+
+var (
+	requestObserverMu sync.RWMutex
+	requestObserver   func(RequestTiming)
+)
+
+// SetRequestObserver registers fn to be called after every HTTP round trip
+// made by a client from this package, e.g. to summarize where the time of a
+// command went. fn may be called concurrently. A nil fn removes the observer.
+func SetRequestObserver(fn func(RequestTiming)) {
+	requestObserverMu.Lock()
+	defer requestObserverMu.Unlock()
+	requestObserver = fn
+}
+
+type requestLabelKey struct{}
+
+// WithRequestLabel returns a context whose requests are reported to the
+// request observer as label, for requests the URL does not tell apart, such
+// as GraphQL operations that all go to the same endpoint
+func WithRequestLabel(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, requestLabelKey{}, label)
+}
+
+// notifyRequestObserver reports a round trip to the request observer, if any
+func notifyRequestObserver(req *http.Request, resp *http.Response, err error, duration time.Duration) {
+	requestObserverMu.RLock()
+	observer := requestObserver
+	requestObserverMu.RUnlock()
+	if observer == nil {
+		return
+	}
+
+	timing := RequestTiming{
+		Method:   req.Method,
+		Duration: duration,
+		Err:      err,
+	}
+	if label, ok := req.Context().Value(requestLabelKey{}).(string); ok && label != "" {
+		timing.Endpoint = label
+	} else {
+		timing.Endpoint, _, _ = strings.Cut(sanitizeURL(req.URL.String()), "?")
+	}
+	if resp != nil {
+		timing.Status = resp.StatusCode
+	}
+	observer(timing)
+}
+
 // logRequest logs the full HTTP request for debugging
 func (t *instrumentedTransport) logRequest(req *http.Request, requestID string) {
 	logger.Debug("=== HTTP Request [%s] ===", requestID)
-	logger.Debug("%s %s", req.Method, req.URL.String())
+	logger.Debug("%s %s", req.Method, sanitizeURL(req.URL.String()))
 
 	// Log headers (sanitized)
 	for key, values := range req.Header {
@@ -119,11 +263,15 @@ func (t *instrumentedTransport) logRequest(req *http.Request, requestID string)
 		}
 	}
 
-	// Log body if present and debug dump is needed
-	if req.Body != nil && req.ContentLength > 0 && req.ContentLength < 10240 {
-		dump, err := httputil.DumpRequestOut(req, true)
-		if err == nil {
-			logger.Debug("Request body:\n%s", sanitizeBody(string(dump)))
+	// Log body if present and small. Only the body is dumped: a full
+	// httputil dump would repeat the headers without redaction.
+	if req.GetBody != nil && req.ContentLength > 0 && req.ContentLength < 10240 {
+		if body, err := req.GetBody(); err == nil {
+			data, err := io.ReadAll(body)
+			body.Close()
+			if err == nil {
+				logger.Debug("Request body:\n%s", sanitizeBody(string(data)))
+			}
 		}
 	}
 }
@@ -156,14 +304,34 @@ func (t *instrumentedTransport) logResponse(resp *http.Response, err error, requ
 	}
 }
 
-// DoWithRetry performs an HTTP request with exponential backoff retry
+// maxRetryAfter caps how long a server-provided Retry-After can delay a retry
+const maxRetryAfter = 60 * time.Second
+
+// DoWithRetry performs an HTTP request with exponential backoff retry.
+// On 429 and 503 responses a Retry-After header, if present, sets the minimum
+// delay before the next attempt (capped at maxRetryAfter).
 func DoWithRetry(ctx context.Context, client *http.Client, req *http.Request, maxRetries int, baseDelay time.Duration) (*http.Response, error) {
+	return DoWithRetryLimited(ctx, client, req, maxRetries, baseDelay, nil)
+}
+
+// DoWithRetryLimited is DoWithRetry with every attempt, retries included,
+// waiting for limiter first. A nil limiter never blocks.
+func DoWithRetryLimited(ctx context.Context, client *http.Client, req *http.Request, maxRetries int, baseDelay time.Duration, limiter *RateLimiter) (*http.Response, error) {
 	var lastErr error
+	var retryAfter time.Duration
+	var lastRequestID string
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
 			delay := calculateBackoff(attempt, baseDelay)
-			logger.Debug("Retry attempt %d/%d after %v", attempt, maxRetries, delay)
+			if retryAfter > delay {
+				delay = retryAfter
+			}
+			if lastRequestID != "" {
+				logger.Debug("Retry attempt %d/%d after %v (previous request %s)", attempt, maxRetries, delay, lastRequestID)
+			} else {
+				logger.Debug("Retry attempt %d/%d after %v", attempt, maxRetries, delay)
+			}
 
 			select {
 			case <-ctx.Done():
@@ -175,6 +343,13 @@ func DoWithRetry(ctx context.Context, client *http.Client, req *http.Request, ma
 			req = cloneRequest(req)
 		}
 
+		retryAfter = 0
+		lastRequestID = ""
+
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
 		resp, err := client.Do(req)
 		if err != nil {
 			lastErr = err
@@ -187,7 +362,19 @@ func DoWithRetry(ctx context.Context, client *http.Client, req *http.Request, ma
 
 		// Check if status code is retryable
 		if isRetryableStatus(resp.StatusCode) {
-			lastErr = fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+			lastErr = &StatusError{StatusCode: resp.StatusCode, Message: resp.Status}
+			if resp.Request != nil {
+				lastRequestID = resp.Request.Header.Get("X-Request-ID")
+			}
+			if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+				if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+					if d > maxRetryAfter {
+						d = maxRetryAfter
+					}
+					retryAfter = d
+					logger.Debug("Server asked to retry after %v (request %s)", d, lastRequestID)
+				}
+			}
 			resp.Body.Close()
 			continue
 		}
@@ -198,6 +385,31 @@ func DoWithRetry(ctx context.Context, client *http.Client, req *http.Request, ma
 	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
+// parseRetryAfter parses a Retry-After header value, given either as
+// delay-seconds or as an HTTP-date. Dates in the past yield a zero delay.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
 // calculateBackoff calculates exponential backoff with jitter
 func calculateBackoff(attempt int, baseDelay time.Duration) time.Duration {
 	// Exponential backoff: baseDelay * 2^attempt
@@ -216,6 +428,37 @@ func calculateBackoff(attempt int, baseDelay time.Duration) time.Duration {
 	return delay
 }
 
+// StatusError is an HTTP response status that was treated as a failure
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
+}
+
+// IsUnsentError reports whether err is a failure that happened before the
+// server could act on the request: a connection or host lookup that failed,
+// or a 429 status. A request that is not idempotent can be sent again after
+// such an error; after a 5xx or a reset connection it may already have
+// been applied.
+func IsUnsentError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
 // isRetryableError checks if an error is retryable
 func isRetryableError(err error) bool {
 	if err == nil {
@@ -284,10 +527,14 @@ func isRetryableStatus(status int) bool {
 	}
 }
 
-// cloneRequest creates a clone of an HTTP request
+// cloneRequest creates a clone of an HTTP request with a fresh body
 func cloneRequest(req *http.Request) *http.Request {
 	clone := req.Clone(req.Context())
-	if req.Body != nil {
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			clone.Body = body
+		}
+	} else if req.Body != nil {
 		if body, ok := req.Body.(io.Seeker); ok {
 			body.Seek(0, io.SeekStart)
 		}
@@ -325,31 +572,80 @@ func generateRequestID() string {
 	return string(b)
 }
 
-// sanitizeURL removes sensitive query parameters from URLs
-func sanitizeURL(url string) string {
-	// Remove common sensitive parameters
-	sensitiveParams := []string{"token", "key", "secret", "password", "access_token", "api_key"}
-	result := url
+// sensitiveURLParams are query parameters whose values are redacted from
+// logged URLs, compared case-insensitively. Presigned S3 and GCS download
+// URLs carry their credentials in the X-Amz-* and X-Goog-* parameters.
+var sensitiveURLParams = map[string]bool{
+	"token":                true,
+	"key":                  true,
+	"secret":               true,
+	"password":             true,
+	"access_token":         true,
+	"api_key":              true,
+	"signature":            true,
+	"sig":                  true,
+	"x-amz-credential":     true,
+	"x-amz-signature":      true,
+	"x-amz-security-token": true,
+	"x-goog-credential":    true,
+	"x-goog-signature":     true,
+}
+
+// sensitivePathSegments mark a path segment whose successor is a credential,
+// e.g. /download/token/<token>/file.zip
+var sensitivePathSegments = map[string]bool{
+	"token":     true,
+	"tokens":    true,
+	"signature": true,
+	"key":       true,
+}
+
+// sanitizeURL redacts credentials from a URL for logging: user info,
+// sensitive query parameter values and path segments that follow a
+// sensitive segment. The URL is parsed rather than string-sliced, so every
+// occurrence is handled exactly once.
+func sanitizeURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		// Keep the part before the query, which is where credentials usually live
+		if idx := strings.Index(rawURL, "?"); idx != -1 {
+			return rawURL[:idx] + "?[REDACTED]"
+		}
+		return rawURL
+	}
+
+	var sb strings.Builder
+	if u.Scheme != "" {
+		sb.WriteString(u.Scheme + "://")
+	}
+	if u.User != nil {
+		sb.WriteString("[REDACTED]@")
+	}
+	sb.WriteString(u.Host)
 
-	for _, param := range sensitiveParams {
-		// Match param=value pattern
-		patterns := []string{
-			param + "=",
+	segments := strings.Split(u.EscapedPath(), "/")
+	for i := 1; i < len(segments); i++ {
+		if sensitivePathSegments[strings.ToLower(segments[i-1])] && segments[i] != "" {
+			segments[i] = "[REDACTED]"
 		}
-		for _, pattern := range patterns {
-			if idx := strings.Index(strings.ToLower(result), pattern); idx != -1 {
-				// Find the end of the value (next & or end of string)
-				endIdx := strings.Index(result[idx:], "&")
-				if endIdx == -1 {
-					result = result[:idx] + param + "=[REDACTED]"
-				} else {
-					result = result[:idx] + param + "=[REDACTED]" + result[idx+endIdx:]
-				}
+	}
+	sb.WriteString(strings.Join(segments, "/"))
+
+	if u.RawQuery != "" {
+		params := strings.Split(u.RawQuery, "&")
+		for i, param := range params {
+			name, _, _ := strings.Cut(param, "=")
+			if unescaped, err := url.QueryUnescape(name); err == nil {
+				name = unescaped
+			}
+			if sensitiveURLParams[strings.ToLower(name)] {
+				params[i] = name + "=[REDACTED]"
 			}
 		}
+		sb.WriteString("?" + strings.Join(params, "&"))
 	}
 
-	return result
+	return sb.String()
 }
 
 // sanitizeBody removes sensitive data from request/response bodies
@@ -365,7 +661,14 @@ func sanitizeBody(body string) string {
 
 	result := body
 	for _, pattern := range sensitivePatterns {
-		if idx := strings.Index(strings.ToLower(result), strings.ToLower(pattern)); idx != -1 {
+		// Redact every occurrence, not just the first
+		from := 0
+		for {
+			idx := strings.Index(strings.ToLower(result[from:]), strings.ToLower(pattern))
+			if idx == -1 {
+				break
+			}
+			idx += from
 			// Find the value and redact it
 			start := idx + len(pattern)
 			// Skip whitespace and opening quote
@@ -379,7 +682,9 @@ func sanitizeBody(body string) string {
 			}
 			if start < end {
 				result = result[:start] + "[REDACTED]" + result[end:]
+				end = start + len("[REDACTED]")
 			}
+			from = end
 		}
 	}
 
@@ -394,6 +699,8 @@ func isSensitiveHeader(name string) bool {
 		"set-cookie",
 		"x-api-key",
 		"x-auth-token",
+		"x-github-token",
+		"proxy-authorization",
 	}
 
 	lower := strings.ToLower(name)
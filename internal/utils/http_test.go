@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDoWithRetryLimitedWaitsBeforeEveryAttempt(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// One token up front, then one every 50ms: the two retries must each
+	// wait for a token on top of the (tiny) backoff
+	limiter := NewRateLimiter(20, 1)
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	resp, err := DoWithRetryLimited(context.Background(), server.Client(), req, 3, time.Millisecond, limiter)
+	if err != nil {
+		t.Fatalf("DoWithRetryLimited: %v", err)
+	}
+	resp.Body.Close()
+
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("took %v, want the limiter to space out all 3 attempts", elapsed)
+	}
+}
+
+func TestDoWithRetryLimitedNoRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := DoWithRetryLimited(context.Background(), server.Client(), req, 0, time.Millisecond, nil); err == nil {
+		t.Fatal("DoWithRetryLimited succeeded on a 502, want an error")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("attempts = %d, want exactly 1 when retries are disabled", got)
+	}
+}
+
+func TestIsUnsentError(t *testing.T) {
+	connRefused := &url.Error{Op: "Post", URL: "https://example.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+	connReset := &url.Error{Op: "Post", URL: "https://example.com", Err: &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}}
+	noHost := &url.Error{Op: "Post", URL: "https://example.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "example.com"}}}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"429", fmt.Errorf("server returned an error: %w", &StatusError{StatusCode: 429}), true},
+		{"500", fmt.Errorf("server returned an error: %w", &StatusError{StatusCode: 500}), false},
+		{"connection refused", fmt.Errorf("failed to send request: %w", wrapNetworkError(connRefused)), true},
+		{"no such host", fmt.Errorf("failed to send request: %w", wrapNetworkError(noHost)), true},
+		{"connection reset", fmt.Errorf("failed to send request: %w", wrapNetworkError(connReset)), false},
+		{"graphql error", errors.New("graphql error: Foreign key violation"), false},
+		{"cancelled", &url.Error{Op: "Post", URL: "https://example.com", Err: context.Canceled}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsUnsentError(tt.err); got != tt.want {
+				t.Errorf("IsUnsentError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token-bucket limiter for outgoing requests
+type RateLimiter struct {
+	mu       sync.Mutex
+	rate     float64 // tokens added per second
+	burst    float64 // bucket capacity
+	tokens   float64
+	lastFill time.Time
+}
+
+// NewRateLimiter creates a limiter allowing ratePerSecond requests on
+// average with bursts of up to burst requests. Returns nil if ratePerSecond
+// is not positive, meaning no limit.
+func NewRateLimiter(ratePerSecond float64, burst int) *RateLimiter {
+	if ratePerSecond <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:     ratePerSecond,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		lastFill: time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+// A nil limiter never blocks.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		delay := l.reserve()
+		if delay == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// reserve takes a token if one is available and returns 0, otherwise it
+// returns how long until the next token is added
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.lastFill).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.lastFill = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
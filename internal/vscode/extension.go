@@ -1,18 +1,23 @@
 package vscode
 
 import (
+	"archive/zip"
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 	"time"
 
 	"github.com/momorph/cli/internal/logger"
+	"github.com/momorph/cli/internal/utils"
 )
 
 const (
@@ -24,8 +29,18 @@ const (
 	ExtensionName = "momorph.vscode-morpheus"
 	// HTTPTimeout is the timeout for HTTP requests
 	HTTPTimeout = 30 * time.Second
+	// DownloadTimeout is the timeout for downloading the VSIX file
+	DownloadTimeout = 60 * time.Second
 )
 
+// ErrCorruptVSIX is returned when the downloaded file is not a readable
+// zip archive, e.g. a truncated download or an HTML error page
+var ErrCorruptVSIX = errors.New("downloaded extension is corrupt")
+
+// ErrReleaseNotFound is returned when the release server answers 404 for
+// latest.txt or the VSIX it names
+var ErrReleaseNotFound = errors.New("extension release not found")
+
 // InstallResult represents the result of a VS Code extension installation
 type InstallResult struct {
 	Installed bool
@@ -33,8 +48,106 @@ type InstallResult struct {
 	Error     error
 }
 
-// InstallExtension attempts to install the MoMorph VS Code extension
-func InstallExtension() InstallResult {
+// InstallExtension attempts to install the MoMorph VS Code extension. An
+// empty version installs the latest release unless the extension is already
+// installed; a pinned version (see ResolveVSIXFilename) is always installed.
+func InstallExtension(ctx context.Context, version string) InstallResult {
+	return installExtension(ctx, version, false)
+}
+
+// UpdateExtension installs the latest (or the pinned) MoMorph VS Code
+// extension, replacing the installed one if any
+func UpdateExtension(ctx context.Context, version string) InstallResult {
+	return installExtension(ctx, version, true)
+}
+
+// vsixPackageName is the extension's package name; VSIX files are published
+// as <package>-<version>.vsix
+const vsixPackageName = "vscode-morpheus"
+
+var (
+	// vsixVersionPattern matches a bare extension version, e.g. 1.4.2 or 1.5.0-beta.1
+	vsixVersionPattern = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.]+)?$`)
+	// vsixFilenamePattern matches a VSIX file name as listed in latest.txt
+	vsixFilenamePattern = regexp.MustCompile(`^[0-9A-Za-z][0-9A-Za-z._-]*\.vsix$`)
+)
+
+// ResolveVSIXFilename turns a pinned extension version into the VSIX file
+// name to download. version is either a version number (1.4.2, giving
+// vscode-morpheus-1.4.2.vsix), optionally prefixed with "v", or a full file
+// name ending in .vsix.
+func ResolveVSIXFilename(version string) (string, error) {
+	version = strings.TrimSpace(version)
+	// Only a version number loses its "v": file names such as
+	// vscode-morpheus-1.4.2.vsix start with one too
+	if bare := strings.TrimPrefix(version, "v"); vsixVersionPattern.MatchString(bare) {
+		version = bare
+	}
+	switch {
+	case vsixVersionPattern.MatchString(version):
+		return vsixPackageName + "-" + version + ".vsix", nil
+	case vsixFilenamePattern.MatchString(version):
+		return version, nil
+	default:
+		return "", fmt.Errorf("invalid extension version %q (expected e.g. 1.4.2 or %s-1.4.2.vsix)", version, vsixPackageName)
+	}
+}
+
+// UninstallExtension removes the MoMorph VS Code extension. Installed is
+// false on success.
+func UninstallExtension() InstallResult {
+	codePath, err := findVSCodeCLI()
+	if err != nil {
+		return InstallResult{
+			Installed: false,
+			Message:   "VS Code not found, nothing to uninstall",
+		}
+	}
+
+	if !isExtensionInstalled(codePath) {
+		return InstallResult{
+			Installed: false,
+			Message:   "MoMorph extension is not installed",
+		}
+	}
+
+	cmd := exec.Command(codePath, "--uninstall-extension", ExtensionName)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		logger.Debug("Extension uninstall stderr: %s", stderr.String())
+		return InstallResult{
+			Installed: true,
+			Message:   fmt.Sprintf("Failed to uninstall extension: %v", err),
+			Error:     err,
+		}
+	}
+
+	return InstallResult{
+		Installed: false,
+		Message:   "MoMorph VS Code extension uninstalled",
+	}
+}
+
+// installExtension installs the given or latest VSIX. Unless force is set or
+// a version is pinned, an installed extension is left alone.
+func installExtension(ctx context.Context, version string, force bool) InstallResult {
+	// Check a pinned version before looking for VS Code
+	var vsixFilename string
+	if version != "" {
+		filename, err := ResolveVSIXFilename(version)
+		if err != nil {
+			return InstallResult{
+				Installed: false,
+				Message:   err.Error(),
+				Error:     err,
+			}
+		}
+		vsixFilename = filename
+		force = true
+	}
+
 	// Check if VS Code CLI is available
 	codePath, err := findVSCodeCLI()
 	if err != nil {
@@ -46,7 +159,7 @@ func InstallExtension() InstallResult {
 	}
 
 	// Check if extension is already installed
-	if isExtensionInstalled(codePath) {
+	if !force && isExtensionInstalled(codePath) {
 		return InstallResult{
 			Installed: true,
 			Message:   "MoMorph extension already installed",
@@ -54,24 +167,26 @@ func InstallExtension() InstallResult {
 		}
 	}
 
-	// Get latest version filename
-	vsixFilename, err := getLatestVersion()
-	if err != nil {
-		logger.Debug("Failed to get latest version: %v", err)
-		return InstallResult{
-			Installed: false,
-			Message:   fmt.Sprintf("Failed to get latest extension version: %v", err),
-			Error:     err,
+	// Get latest version filename, unless pinned
+	if vsixFilename == "" {
+		vsixFilename, err = getLatestVersion(ctx)
+		if err != nil {
+			logger.Debug("Failed to get latest version: %v", err)
+			return InstallResult{
+				Installed: false,
+				Message:   describeFetchError("Failed to get latest extension version", err),
+				Error:     err,
+			}
 		}
 	}
 
 	// Download VSIX file
-	vsixPath, err := downloadVSIX(vsixFilename)
+	vsixPath, err := downloadVSIX(ctx, vsixFilename)
 	if err != nil {
 		logger.Debug("Failed to download VSIX: %v", err)
 		return InstallResult{
 			Installed: false,
-			Message:   fmt.Sprintf("Failed to download extension: %v", err),
+			Message:   describeFetchError("Failed to download extension", err),
 			Error:     err,
 		}
 	}
@@ -94,24 +209,62 @@ func InstallExtension() InstallResult {
 
 	return InstallResult{
 		Installed: true,
-		Message:   "MoMorph VS Code extension installed successfully",
+		Message:   fmt.Sprintf("MoMorph VS Code extension installed successfully (%s)", vsixFilename),
 		Error:     nil,
 	}
 }
 
-// getLatestVersion fetches the latest VSIX filename from the server
-func getLatestVersion() (string, error) {
-	client := &http.Client{Timeout: HTTPTimeout}
+// describeFetchError turns a getLatestVersion/downloadVSIX error into an
+// InstallResult message that tells a missing release apart from a network
+// problem
+func describeFetchError(prefix string, err error) string {
+	if errors.Is(err, context.Canceled) {
+		return fmt.Sprintf("%s: cancelled", prefix)
+	}
+	if errors.Is(err, ErrCorruptVSIX) {
+		return fmt.Sprintf("%s: %v (try again later)", prefix, err)
+	}
+	if errors.Is(err, ErrReleaseNotFound) {
+		return fmt.Sprintf("%s: %v (the release may have been removed; try again later)", prefix, err)
+	}
+	return fmt.Sprintf("%s: network error: %v (check your connection and try again)", prefix, err)
+}
 
-	resp, err := client.Get(LatestVersionURL)
+// fetch GETs url from the release server, retrying transient failures.
+// A 404 is reported as ErrReleaseNotFound. The caller closes the body;
+// cancelling ctx aborts the request, including reading the body.
+func fetch(ctx context.Context, url string, timeout time.Duration) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch latest version: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	cfg := utils.DefaultHTTPConfig()
+	client := utils.NewHTTPClientWithTimeout(timeout)
+	resp, err := utils.DoWithRetry(ctx, client, req, cfg.MaxRetries, cfg.RetryBaseDelay)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s", ErrReleaseNotFound, url)
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
 	}
+}
+
+// getLatestVersion fetches the latest VSIX filename from the server
+func getLatestVersion(ctx context.Context) (string, error) {
+	resp, err := fetch(ctx, LatestVersionURL, HTTPTimeout)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -128,21 +281,15 @@ func getLatestVersion() (string, error) {
 }
 
 // downloadVSIX downloads the VSIX file to a temporary location
-func downloadVSIX(filename string) (string, error) {
+func downloadVSIX(ctx context.Context, filename string) (string, error) {
 	downloadURL := DownloadBaseURL + filename
 
-	client := &http.Client{Timeout: 60 * time.Second}
-
-	resp, err := client.Get(downloadURL)
+	resp, err := fetch(ctx, downloadURL, DownloadTimeout)
 	if err != nil {
-		return "", fmt.Errorf("failed to download VSIX: %w", err)
+		return "", err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("download failed with status: %d", resp.StatusCode)
-	}
-
 	// Create temp file
 	tempFile, err := os.CreateTemp("", "momorph-*.vsix")
 	if err != nil {
@@ -158,10 +305,34 @@ func downloadVSIX(filename string) (string, error) {
 		return "", fmt.Errorf("failed to write VSIX file: %w", err)
 	}
 
+	// A VSIX is a zip; check it before handing it to the VS Code CLI, whose
+	// error for a bad file does not say what is wrong
+	if err := verifyVSIX(tempPath); err != nil {
+		os.Remove(tempPath)
+		return "", err
+	}
+
 	logger.Debug("Downloaded VSIX to: %s", tempPath)
 	return tempPath, nil
 }
 
+// verifyVSIX checks that path is a readable zip archive containing an
+// extension manifest
+func verifyVSIX(path string) error {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrCorruptVSIX, err)
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if f.Name == "extension.vsixmanifest" || f.Name == "extension/package.json" {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: no extension manifest in archive", ErrCorruptVSIX)
+}
+
 // findVSCodeCLI finds the VS Code CLI command based on the platform
 func findVSCodeCLI() (string, error) {
 	// Common CLI names to try
@@ -0,0 +1,41 @@
+package vscode
+
+import "testing"
+
+func TestResolveVSIXFilename(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+		wantErr bool
+	}{
+		{version: "1.4.2", want: "vscode-morpheus-1.4.2.vsix"},
+		{version: "v1.4.2", want: "vscode-morpheus-1.4.2.vsix"},
+		{version: " v1.5.0-beta.1 ", want: "vscode-morpheus-1.5.0-beta.1.vsix"},
+		{version: "vscode-morpheus-1.4.2.vsix", want: "vscode-morpheus-1.4.2.vsix"},
+		{version: "vscode-morpheus-1.5.0-beta.1.vsix", want: "vscode-morpheus-1.5.0-beta.1.vsix"},
+		{version: "morpheus-nightly.vsix", want: "morpheus-nightly.vsix"},
+		{version: "", wantErr: true},
+		{version: "v", wantErr: true},
+		{version: "1.4", wantErr: true},
+		{version: "../vscode-morpheus-1.4.2.vsix", wantErr: true},
+		{version: "vscode-morpheus-1.4.2.zip", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := ResolveVSIXFilename(tt.version)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ResolveVSIXFilename(%q) = %q, want error", tt.version, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveVSIXFilename(%q): %v", tt.version, err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveVSIXFilename(%q) = %q, want %q", tt.version, got, tt.want)
+			}
+		})
+	}
+}